@@ -1,5 +1,13 @@
 package mikrotik
 
+import (
+	"errors"
+	"net"
+	"strings"
+
+	"github.com/go-routeros/routeros"
+)
+
 type NotFound struct {
 	s string
 }
@@ -11,3 +19,78 @@ func NewNotFound(text string) error {
 func (e *NotFound) Error() string {
 	return e.s
 }
+
+// dialError is a failure to connect to the router, or to talk to it while
+// logging in. No command has been sent yet, so it can always be retried.
+type dialError struct {
+	err error
+}
+
+func (e *dialError) Error() string {
+	return e.err.Error()
+}
+
+// retryableTrapMessages are the `!trap` messages which RouterOS returns for
+// transient conditions. They are matched as a whole, so that traps merely
+// mentioning an argument such as `timeout` are not mistaken for them.
+var retryableTrapMessages = map[string]bool{
+	"action timed out - try again": true,
+	"action timed out - try again, if error continues contact mikrotik support and send a supout file (13)": true,
+	"interrupted": true,
+}
+
+// isSessionError reports whether err means the API session can no longer
+// be used. Errors reported by the device with `!trap` leave the session
+// intact while `!fatal` and any transport error do not.
+func isSessionError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if deviceErr, ok := err.(*routeros.DeviceError); ok {
+		return deviceErr.Sentence.Word == "!fatal"
+	}
+	return true
+}
+
+// isReadOnlyCommand reports whether cmd only prints, so running it twice
+// cannot change the router.
+func isReadOnlyCommand(cmd []string) bool {
+	return len(cmd) > 0 && strings.HasSuffix(cmd[0], "/print")
+}
+
+// isRetryableError reports whether cmd, having failed with err, may safely
+// be run again. Any other command than a print may have been applied before
+// the failure was noticed, so it is only retried when it was never sent.
+// Traps describing invalid input or missing items are permanent.
+func isRetryableError(cmd []string, err error) bool {
+	if err == nil {
+		return false
+	}
+	if isDialError(err) {
+		return true
+	}
+	if !isReadOnlyCommand(cmd) {
+		return false
+	}
+	if _, ok := err.(*NotFound); ok {
+		return false
+	}
+
+	deviceErr, ok := err.(*routeros.DeviceError)
+	if !ok || deviceErr.Sentence.Word == "!fatal" {
+		return true
+	}
+
+	message := strings.ToLower(strings.TrimSpace(deviceErr.Sentence.Map["message"]))
+	return retryableTrapMessages[message]
+}
+
+// isDialError reports whether err occurred before any command was sent,
+// including the failure of the REST transport to connect.
+func isDialError(err error) bool {
+	if _, ok := err.(*dialError); ok {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
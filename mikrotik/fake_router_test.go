@@ -0,0 +1,550 @@
+package mikrotik
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/go-routeros/routeros"
+	"github.com/go-routeros/routeros/proto"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+// fakeRouter is an in-memory model of the RouterOS menus used by the offline
+// tests. Every menu path, such as `/ip/address`, holds a table of items which
+// can be manipulated with the generic add, set, unset, remove, move and
+// print commands. Like on ordered menus, add accepts place-before, and like
+// the router the /32 of single IPv4 addresses is dropped, connection states
+// are reordered, address list entries with a timeout are dynamic, the public
+// key of a wireguard interface follows its private key and an empty value is
+// rejected for anything but free text, which has to be unset instead.
+type fakeRouter struct {
+	mu       sync.Mutex
+	nextId   int
+	tables   map[string][]map[string]string
+	commands [][]string
+}
+
+func newFakeRouter() *fakeRouter {
+	return &fakeRouter{
+		tables: map[string][]map[string]string{},
+	}
+}
+
+// run executes an API sentence and returns the reply the router would send.
+func (f *fakeRouter) run(cmd []string) (*routeros.Reply, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if len(cmd) == 0 {
+		return nil, fakeTrap("empty command")
+	}
+	f.commands = append(f.commands, cmd)
+
+	idx := strings.LastIndex(cmd[0], "/")
+	menu, verb := cmd[0][:idx], cmd[0][idx+1:]
+
+	attributes := map[string]string{}
+	var attributeOrder []string
+	var queries []string
+	for _, word := range cmd[1:] {
+		switch {
+		case strings.HasPrefix(word, "="):
+			pair := strings.SplitN(word[1:], "=", 2)
+			if len(pair) == 1 {
+				pair = append(pair, "")
+			}
+			attributes[pair[0]] = pair[1]
+			attributeOrder = append(attributeOrder, pair[0])
+		case strings.HasPrefix(word, "?"):
+			queries = append(queries, word[1:])
+		}
+	}
+
+	if verb == "add" || verb == "set" {
+		for _, k := range attributeOrder {
+			if attributes[k] == "" && !fakeFreeText[k] {
+				return nil, fakeTrap(fmt.Sprintf("invalid value for argument %s", k))
+			}
+		}
+	}
+
+	done := &proto.Sentence{Word: "!done", Map: map[string]string{}}
+	reply := &routeros.Reply{Done: done}
+
+	switch verb {
+	case "add":
+		f.nextId++
+		id := fmt.Sprintf("*%X", f.nextId)
+		item := map[string]string{".id": id}
+		for k, v := range attributes {
+			if v != "" && !strings.HasPrefix(k, ".") && k != "place-before" {
+				item[k] = fakeValue(k, v)
+			}
+		}
+		if item["timeout"] != "" && strings.HasSuffix(menu, "/address-list") {
+			item["dynamic"] = "true"
+		}
+		fakeDerive(menu, item)
+		position := len(f.tables[menu])
+		if before := attributes["place-before"]; before != "" {
+			position = -1
+			for i, existing := range f.tables[menu] {
+				if existing[".id"] == before {
+					position = i
+				}
+			}
+			if position < 0 {
+				return nil, fakeTrap("no such item")
+			}
+		}
+		items := f.tables[menu]
+		f.tables[menu] = append(items[:position:position], append([]map[string]string{item}, items[position:]...)...)
+		done.Map["ret"] = id
+		done.List = append(done.List, proto.Pair{Key: "ret", Value: id})
+
+	case "set":
+		item, err := f.find(menu, attributes[".id"])
+		if err != nil {
+			return nil, err
+		}
+		for _, k := range attributeOrder {
+			if strings.HasPrefix(k, ".") {
+				continue
+			}
+			if attributes[k] == "" {
+				delete(item, k)
+			} else {
+				item[k] = fakeValue(k, attributes[k])
+			}
+		}
+		fakeDerive(menu, item)
+
+	case "unset":
+		item, err := f.find(menu, attributes["numbers"])
+		if err != nil {
+			return nil, err
+		}
+		delete(item, attributes["value-name"])
+
+	case "remove":
+		for _, id := range strings.Split(attributes[".id"], ",") {
+			if _, err := f.find(menu, id); err != nil {
+				return nil, err
+			}
+			items := f.tables[menu]
+			for i, item := range items {
+				if item[".id"] == id {
+					f.tables[menu] = append(items[:i:i], items[i+1:]...)
+					break
+				}
+			}
+		}
+
+	case "move":
+		var moved []map[string]string
+		for _, id := range strings.Split(attributes["numbers"], ",") {
+			item, err := f.find(menu, id)
+			if err != nil {
+				return nil, err
+			}
+			moved = append(moved, item)
+		}
+		if destination := attributes["destination"]; destination != "" {
+			if _, err := f.find(menu, destination); err != nil {
+				return nil, err
+			}
+		}
+
+		var items []map[string]string
+		for _, item := range f.tables[menu] {
+			if !strings.Contains(","+attributes["numbers"]+",", ","+item[".id"]+",") {
+				items = append(items, item)
+			}
+		}
+		position := len(items)
+		for i, item := range items {
+			if item[".id"] == attributes["destination"] {
+				position = i
+			}
+		}
+		f.tables[menu] = append(items[:position:position], append(moved, items[position:]...)...)
+
+	case "print":
+		for _, item := range f.tables[menu] {
+			if fakeMatches(item, queries) {
+				reply.Re = append(reply.Re, fakeSentence(item))
+			}
+		}
+
+	default:
+		return nil, fakeTrap("no such command")
+	}
+
+	return reply, nil
+}
+
+func (f *fakeRouter) find(menu, id string) (map[string]string, error) {
+	for _, item := range f.tables[menu] {
+		if item[".id"] == id {
+			return item, nil
+		}
+	}
+	return nil, fakeTrap("no such item")
+}
+
+// items returns a copy of the items in menu for assertions in tests.
+func (f *fakeRouter) items(menu string) []map[string]string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var items []map[string]string
+	for _, item := range f.tables[menu] {
+		c := map[string]string{}
+		for k, v := range item {
+			c[k] = v
+		}
+		items = append(items, c)
+	}
+	return items
+}
+
+// lastCommand returns the last command received for the given menu and verb,
+// such as `/ip/address/set`.
+func (f *fakeRouter) lastCommand(command string) []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for i := len(f.commands) - 1; i >= 0; i-- {
+		if f.commands[i][0] == command {
+			return f.commands[i]
+		}
+	}
+	return nil
+}
+
+// fakeFreeText are the attributes which may be set to an empty value.
+var fakeFreeText = map[string]bool{
+	"comment": true,
+}
+
+// fakeValue returns value as the router stores it.
+func fakeValue(name, value string) string {
+	switch name {
+	case "address", "src-address", "dst-address":
+		if !strings.Contains(value, ":") {
+			return strings.TrimSuffix(value, "/32")
+		}
+	case "connection-state":
+		states := strings.Split(value, ",")
+		sort.Strings(states)
+		return strings.Join(states, ",")
+	}
+	return value
+}
+
+// fakeDerive updates the attributes of item which the router derives from
+// others.
+func fakeDerive(menu string, item map[string]string) {
+	if menu == "/interface/wireguard" && item["private-key"] != "" {
+		item["public-key"] = fakePublicKey(item["private-key"])
+	}
+}
+
+// fakePublicKey stands in for the curve25519 public key of privateKey.
+func fakePublicKey(privateKey string) string {
+	sum := sha256.Sum256([]byte(privateKey))
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// fakeMatches supports the equality (`?name=value`), presence (`?name`) and
+// absence (`?-name`) queries, combined with an implicit and.
+func fakeMatches(item map[string]string, queries []string) bool {
+	for _, q := range queries {
+		switch {
+		case strings.HasPrefix(q, "-"):
+			if _, ok := item[q[1:]]; ok {
+				return false
+			}
+		case strings.Contains(q, "="):
+			pair := strings.SplitN(q, "=", 2)
+			if item[pair[0]] != pair[1] {
+				return false
+			}
+		default:
+			if _, ok := item[q]; !ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func fakeSentence(item map[string]string) *proto.Sentence {
+	sentence := &proto.Sentence{Word: "!re", Map: map[string]string{}}
+
+	keys := make([]string, 0, len(item))
+	for k := range item {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		sentence.List = append(sentence.List, proto.Pair{Key: k, Value: item[k]})
+		sentence.Map[k] = item[k]
+	}
+	return sentence
+}
+
+func fakeTrap(message string) error {
+	return &routeros.DeviceError{Sentence: &proto.Sentence{
+		Word: "!trap",
+		List: []proto.Pair{{Key: "message", Value: message}},
+		Map:  map[string]string{"message": message},
+	}}
+}
+
+// newFakeApiServer serves the binary API sentence protocol for f on a
+// random localhost port and returns its address. Only the admin/password
+// credentials are accepted.
+func newFakeApiServer(t *testing.T, f *fakeRouter) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { l.Close() })
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go f.serve(conn)
+		}
+	}()
+
+	return l.Addr().String()
+}
+
+func (f *fakeRouter) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	w := proto.NewWriter(conn)
+	loggedIn := false
+
+	for {
+		cmd, err := readFakeSentence(r)
+		if err != nil {
+			return
+		}
+		if len(cmd) == 0 {
+			continue
+		}
+
+		var reply *routeros.Reply
+		switch {
+		case cmd[0] == "/login":
+			loggedIn = contains(cmd, "=name=admin") && contains(cmd, "=password=password")
+			if loggedIn {
+				reply = &routeros.Reply{Done: &proto.Sentence{Word: "!done"}}
+			} else {
+				err = fakeTrap("invalid user name or password (6)")
+			}
+		case !loggedIn:
+			err = fakeTrap("not logged in")
+		default:
+			reply, err = f.run(cmd)
+		}
+
+		if err != nil {
+			writeFakeSentence(w, err.(*routeros.DeviceError).Sentence)
+			continue
+		}
+		for _, re := range reply.Re {
+			writeFakeSentence(w, re)
+		}
+		writeFakeSentence(w, reply.Done)
+	}
+}
+
+// readFakeSentence reads the words of a sentence. Unlike proto.Reader it
+// accepts the query words (`?name=value`) that clients send.
+func readFakeSentence(r *bufio.Reader) ([]string, error) {
+	var words []string
+	for {
+		length, err := readFakeLength(r)
+		if err != nil {
+			return nil, err
+		}
+		if length == 0 {
+			return words, nil
+		}
+		word := make([]byte, length)
+		if _, err := io.ReadFull(r, word); err != nil {
+			return nil, err
+		}
+		words = append(words, string(word))
+	}
+}
+
+func readFakeLength(r *bufio.Reader) (int, error) {
+	b, err := r.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+
+	var length, extra int
+	switch {
+	case b&0x80 == 0x00:
+		return int(b), nil
+	case b&0xC0 == 0x80:
+		length, extra = int(b&0x3F), 1
+	case b&0xE0 == 0xC0:
+		length, extra = int(b&0x1F), 2
+	case b&0xF0 == 0xE0:
+		length, extra = int(b&0x0F), 3
+	default:
+		length, extra = 0, 4
+	}
+
+	for i := 0; i < extra; i++ {
+		b, err := r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		length = length<<8 | int(b)
+	}
+	return length, nil
+}
+
+func writeFakeSentence(w proto.Writer, sentence *proto.Sentence) error {
+	w.BeginSentence()
+	w.WriteWord(sentence.Word)
+	for _, pair := range sentence.List {
+		w.WriteWord("=" + pair.Key + "=" + pair.Value)
+	}
+	return w.EndSentence()
+}
+
+// testFakeProvider returns the provider block pointing at a fake server.
+func testFakeProvider(address string) string {
+	return fmt.Sprintf(`
+provider "mikrotik" {
+	host = "%s"
+	username = "admin"
+	password = "password"
+	retry_backoff = "10ms"
+}
+`, address)
+}
+
+// testFakeRouterEmpty returns a CheckDestroy function verifying every item
+// of menu has been removed from the fake router.
+func testFakeRouterEmpty(f *fakeRouter, menu string) func(*terraform.State) error {
+	return func(s *terraform.State) error {
+		if items := f.items(menu); len(items) > 0 {
+			return fmt.Errorf("%s still has items: %v", menu, items)
+		}
+		return nil
+	}
+}
+
+// testFakeRouterItem returns a check that the item of resourceName exists
+// in menu on the fake router with the expected attributes.
+func testFakeRouterItem(f *fakeRouter, menu, resourceName string, expected map[string]string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resourceName)
+		}
+
+		for _, item := range f.items(menu) {
+			if item[".id"] != rs.Primary.ID {
+				continue
+			}
+			for k, v := range expected {
+				if item[k] != v {
+					return fmt.Errorf("%s `%s` has %s=%q instead of %q", menu, rs.Primary.ID, k, item[k], v)
+				}
+			}
+			return nil
+		}
+		return fmt.Errorf("%s `%s` does not exist on the router", menu, rs.Primary.ID)
+	}
+}
+
+// testFakeRouterLastCommand returns a check that the last command sent to
+// the fake router as command carried exactly the given attribute words,
+// besides the `.id`.
+func testFakeRouterLastCommand(f *fakeRouter, command string, attributes ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		cmd := f.lastCommand(command)
+		if cmd == nil {
+			return fmt.Errorf("%s was never run", command)
+		}
+
+		var actual []string
+		for _, word := range cmd[1:] {
+			if !strings.HasPrefix(word, "=.id=") {
+				actual = append(actual, word)
+			}
+		}
+		if strings.Join(actual, " ") != strings.Join(attributes, " ") {
+			return fmt.Errorf("%s was run with %v instead of %v", command, actual, attributes)
+		}
+		return nil
+	}
+}
+
+// testFakeRouterUnset returns a check that the last update of an item of
+// menu ended with unsetting exactly the given attributes, in order.
+func testFakeRouterUnset(f *fakeRouter, menu string, names ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		var actual []string
+		for i := len(f.commands) - 1; i >= 0; i-- {
+			cmd := f.commands[i]
+			if strings.HasSuffix(cmd[0], "/print") {
+				continue
+			}
+			if cmd[0] != menu+"/unset" {
+				break
+			}
+			for _, word := range cmd[1:] {
+				if strings.HasPrefix(word, "=value-name=") {
+					actual = append([]string{strings.TrimPrefix(word, "=value-name=")}, actual...)
+				}
+			}
+		}
+		if strings.Join(actual, " ") != strings.Join(names, " ") {
+			return fmt.Errorf("%s/unset was run for %v instead of %v", menu, actual, names)
+		}
+		return nil
+	}
+}
+
+// testFakeRouterOrder returns a check that the items of menu on the fake
+// router have the given comments, in order.
+func testFakeRouterOrder(f *fakeRouter, menu string, comments ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		var actual []string
+		for _, item := range f.items(menu) {
+			actual = append(actual, item["comment"])
+		}
+		if strings.Join(actual, " ") != strings.Join(comments, " ") {
+			return fmt.Errorf("Expected the items %v, %s has %v", comments, menu, actual)
+		}
+		return nil
+	}
+}
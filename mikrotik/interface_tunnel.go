@@ -0,0 +1,26 @@
+package mikrotik
+
+// InterfaceTunnel are the attributes shared by the point-to-point tunnels:
+// GRE, EoIP, IPIP and 6to4. The tunnel structs embed them next to the
+// attributes of their encapsulation.
+type InterfaceTunnel struct {
+	Clamp_tcp_mss bool   `mikrotik:"clamp-tcp-mss,default=true"`
+	Comment       string `mikrotik:"comment"`
+	Copy_from     string `mikrotik:"copy-from,writeonly"`
+	Disabled      bool   `mikrotik:"disabled,default=false"`
+	Dont_fragment string `mikrotik:"dont-fragment,default=no"`
+	Dscp          string `mikrotik:"dscp,default=inherit"`
+	Ipsec_secret  string `mikrotik:"ipsec-secret,sensitive"`
+	Keepalive     string `mikrotik:"keepalive,default=10s,10"`
+	Local_address string `mikrotik:"local-address"`
+	Mtu           string `mikrotik:"mtu,default=auto"`
+	Name          string `mikrotik:"name,computed"`
+}
+
+// InterfaceFastPathTunnel adds the attributes of the tunnels to a given
+// remote address which support fast path: GRE, EoIP and IPIP.
+type InterfaceFastPathTunnel struct {
+	Allow_fast_path bool   `mikrotik:"allow-fast-path,default=true"`
+	Remote_address  string `mikrotik:"remote-address,required"`
+	InterfaceTunnel
+}
@@ -0,0 +1,105 @@
+package mikrotik
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// FirewallMatchers are the packet matchers shared by the firewall rules of
+// both address families. Matchers tagged unordered take a comma separated
+// list which the router prints in an order of its own.
+type FirewallMatchers struct {
+	FirewallConnectionMatchers
+	FirewallPacketMatchers
+}
+
+// FirewallConnectionMatchers are the matchers relying on connection
+// tracking, which the raw table runs before.
+type FirewallConnectionMatchers struct {
+	Connection_bytes string `mikrotik:"connection-bytes"`
+	Connection_limit string `mikrotik:"connection-limit"`
+	Connection_mark  string `mikrotik:"connection-mark"`
+	Connection_rate  string `mikrotik:"connection-rate"`
+	Connection_state string `mikrotik:"connection-state,unordered"`
+	Connection_type  string `mikrotik:"connection-type"`
+}
+
+// FirewallPacketMatchers are the matchers of FirewallMatchers looking at a
+// packet on its own.
+type FirewallPacketMatchers struct {
+	Content                   string `mikrotik:"content"`
+	Dscp                      string `mikrotik:"dscp"`
+	Dst_address               string `mikrotik:"dst-address"`
+	Dst_address_list          string `mikrotik:"dst-address-list"`
+	Dst_address_type          string `mikrotik:"dst-address-type"`
+	Dst_limit                 string `mikrotik:"dst-limit"`
+	Dst_port                  string `mikrotik:"dst-port"`
+	Icmp_options              string `mikrotik:"icmp-options"`
+	In_bridge_port            string `mikrotik:"in-bridge-port"`
+	In_bridge_port_list       string `mikrotik:"in-bridge-port-list"`
+	In_interface              string `mikrotik:"in-interface"`
+	In_interface_list         string `mikrotik:"in-interface-list"`
+	Ingress_priority          string `mikrotik:"ingress-priority"`
+	Ipsec_policy              string `mikrotik:"ipsec-policy"`
+	Limit                     string `mikrotik:"limit"`
+	Nth                       string `mikrotik:"nth"`
+	Out_bridge_port           string `mikrotik:"out-bridge-port"`
+	Out_bridge_port_list      string `mikrotik:"out-bridge-port-list"`
+	Out_interface             string `mikrotik:"out-interface"`
+	Out_interface_list        string `mikrotik:"out-interface-list"`
+	Packet_mark               string `mikrotik:"packet-mark"`
+	Packet_size               string `mikrotik:"packet-size"`
+	Per_connection_classifier string `mikrotik:"per-connection-classifier"`
+	Port                      string `mikrotik:"port"`
+	Priority                  string `mikrotik:"priority"`
+	Protocol                  string `mikrotik:"protocol"`
+	Random                    string `mikrotik:"random"`
+	Src_address               string `mikrotik:"src-address"`
+	Src_address_list          string `mikrotik:"src-address-list"`
+	Src_address_type          string `mikrotik:"src-address-type"`
+	Src_mac_address           string `mikrotik:"src-mac-address"`
+	Src_port                  string `mikrotik:"src-port"`
+	Tcp_flags                 string `mikrotik:"tcp-flags,unordered"`
+	Tcp_mss                   string `mikrotik:"tcp-mss"`
+	Time                      string `mikrotik:"time"`
+}
+
+// IpFirewallMatchers are the packet matchers shared by the rules of the
+// `/ip/firewall` tables. The rule structs embed them next to the attributes
+// of their actions.
+type IpFirewallMatchers struct {
+	FirewallConnectionMatchers
+	Connection_nat_state string `mikrotik:"connection-nat-state,unordered"`
+	Layer7_protocol      string `mikrotik:"layer7-protocol"`
+	IpFirewallPacketMatchers
+}
+
+// IpFirewallPacketMatchers are the matchers of IpFirewallMatchers which the
+// raw table accepts, as they do not need connection tracking.
+type IpFirewallPacketMatchers struct {
+	FirewallPacketMatchers
+	Fragment      *bool  `mikrotik:"fragment"`
+	Hotspot       string `mikrotik:"hotspot"`
+	Ipv4_options  string `mikrotik:"ipv4-options"`
+	P2p           string `mikrotik:"p2p"`
+	Psd           string `mikrotik:"psd"`
+	Routing_mark  string `mikrotik:"routing-mark"`
+	Routing_table string `mikrotik:"routing-table"`
+	Tls_host      string `mikrotik:"tls-host"`
+	Ttl           string `mikrotik:"ttl"`
+}
+
+// Ipv6FirewallMatchers are the packet matchers shared by the rules of the
+// `/ipv6/firewall` tables.
+type Ipv6FirewallMatchers struct {
+	FirewallMatchers
+	Hop_limit string `mikrotik:"hop-limit"`
+}
+
+// ipv6FirewallResource returns the resource of an `/ipv6/firewall` rule menu
+// with the address matchers validated as IPv6.
+func ipv6FirewallResource(menu *mikrotikMenu) *schema.Resource {
+	r := menu.resource()
+	r.Schema["src_address"].ValidateFunc = validateIpv6AddressMatcher
+	r.Schema["dst_address"].ValidateFunc = validateIpv6AddressMatcher
+	return r
+}
@@ -0,0 +1,75 @@
+package mikrotik
+
+import (
+	"log"
+	"strings"
+
+	"github.com/go-routeros/routeros"
+	"github.com/go-routeros/routeros/proto"
+)
+
+// secretAttributes are the RouterOS attributes whose values never reach the
+// log, whichever resource sends or prints them. Attributes tagged sensitive
+// must be listed here, which the schema tests check.
+var secretAttributes = map[string]bool{
+	"ipsec-secret":  true,
+	"password":      true,
+	"preshared-key": true,
+	"private-key":   true,
+	"secret":        true,
+}
+
+const redactedValue = "<redacted>"
+
+// logCommand logs a command about to be sent to the router by a transport.
+func logCommand(cmd []string) {
+	log.Printf("[INFO] Running the mikrotik command: `%s`", redactCommand(cmd))
+}
+
+// redactCommand returns a copy of the words of cmd whose attribute and
+// query words, such as `=private-key=...` or `?password=...`, carry no
+// secret values.
+func redactCommand(cmd []string) []string {
+	redacted := make([]string, len(cmd))
+	for i, word := range cmd {
+		redacted[i] = word
+		if len(word) == 0 || (word[0] != '=' && word[0] != '?') {
+			continue
+		}
+		parts := strings.SplitN(word[1:], "=", 2)
+		if len(parts) == 2 && secretAttributes[parts[0]] {
+			redacted[i] = word[:1] + parts[0] + "=" + redactedValue
+		}
+	}
+	return redacted
+}
+
+// redactReply returns a copy of r whose sentences carry no secret values,
+// for logging the items printed by the router.
+func redactReply(r *routeros.Reply) *routeros.Reply {
+	if r == nil {
+		return nil
+	}
+
+	redacted := &routeros.Reply{Done: redactSentence(r.Done)}
+	for _, re := range r.Re {
+		redacted.Re = append(redacted.Re, redactSentence(re))
+	}
+	return redacted
+}
+
+func redactSentence(sentence *proto.Sentence) *proto.Sentence {
+	if sentence == nil {
+		return nil
+	}
+
+	redacted := &proto.Sentence{Word: sentence.Word, Tag: sentence.Tag, Map: map[string]string{}}
+	for _, pair := range sentence.List {
+		if secretAttributes[pair.Key] {
+			pair.Value = redactedValue
+		}
+		redacted.List = append(redacted.List, pair)
+		redacted.Map[pair.Key] = pair.Value
+	}
+	return redacted
+}
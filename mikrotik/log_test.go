@@ -0,0 +1,95 @@
+package mikrotik
+
+import (
+	"bytes"
+	"log"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-routeros/routeros"
+	"github.com/go-routeros/routeros/proto"
+)
+
+func TestMikrotikProvider_RedactCommand(t *testing.T) {
+	cmd := []string{
+		"/interface/gre/add",
+		"=name=gre1",
+		"=ipsec-secret=s3cr3t",
+		"=private-key=a=b=",
+		"?password=hunter2",
+		"=comment=password=visible",
+		"=secret=",
+	}
+	expected := []string{
+		"/interface/gre/add",
+		"=name=gre1",
+		"=ipsec-secret=<redacted>",
+		"=private-key=<redacted>",
+		"?password=<redacted>",
+		"=comment=password=visible",
+		"=secret=<redacted>",
+	}
+
+	if redacted := redactCommand(cmd); !reflect.DeepEqual(redacted, expected) {
+		t.Errorf("redactCommand returned %v, expected %v", redacted, expected)
+	}
+	if cmd[2] != "=ipsec-secret=s3cr3t" {
+		t.Errorf("redactCommand should not modify the command, it now has %s", cmd[2])
+	}
+}
+
+func TestMikrotikProvider_RedactReply(t *testing.T) {
+	sentence := &proto.Sentence{Word: "!re"}
+	sentence.List = []proto.Pair{{Key: "name", Value: "wg1"}, {Key: "private-key", Value: "s3cr3t"}}
+	sentence.Map = map[string]string{"name": "wg1", "private-key": "s3cr3t"}
+	r := &routeros.Reply{Re: []*proto.Sentence{sentence}, Done: &proto.Sentence{Word: "!done", Map: map[string]string{}}}
+
+	redacted := redactReply(r).String()
+	if strings.Contains(redacted, "s3cr3t") || !strings.Contains(redacted, "wg1") {
+		t.Errorf("redactReply returned %s", redacted)
+	}
+	if sentence.Map["private-key"] != "s3cr3t" || sentence.List[1].Value != "s3cr3t" {
+		t.Errorf("redactReply should not modify the reply, it now has %v", sentence)
+	}
+	if redactReply(nil) != nil {
+		t.Error("redactReply should return nil for a nil reply")
+	}
+}
+
+func TestMikrotikLog_secretsNotLogged(t *testing.T) {
+	f := newFakeRouter()
+	c := NewClient(newFakeApiServer(t, f), "admin", "password")
+	defer c.Close()
+
+	var output bytes.Buffer
+	defer log.SetOutput(log.Writer())
+	log.SetOutput(&output)
+
+	gre, err := c.AddInterfaceGre(true, true, "", "", false, "no", "inherit", "gre-s3cr3t", "10s,10", "", "auto", "gre1", "198.51.100.2")
+	if err != nil {
+		t.Fatalf("Failed to add the gre interface with error: %v", err)
+	}
+	if gre.Ipsec_secret != "gre-s3cr3t" {
+		t.Errorf("The router should print the ipsec secret, received %q", gre.Ipsec_secret)
+	}
+
+	wireguard := &InterfaceWireguard{Name: "wg1", Private_key: "wg-s3cr3t"}
+	id, err := c.addItem(interfaceWireguardMenu, wireguard)
+	if err != nil {
+		t.Fatalf("Failed to add the wireguard interface with error: %v", err)
+	}
+	if err := c.findItem(interfaceWireguardMenu, id, &InterfaceWireguard{}); err != nil {
+		t.Fatalf("Failed to find the wireguard interface with error: %v", err)
+	}
+
+	logged := output.String()
+	for _, secret := range []string{"gre-s3cr3t", "wg-s3cr3t"} {
+		if strings.Contains(logged, secret) {
+			t.Errorf("The secret %s was logged:\n%s", secret, logged)
+		}
+	}
+	if !strings.Contains(logged, "=ipsec-secret=<redacted>") {
+		t.Errorf("The redacted command was not logged:\n%s", logged)
+	}
+}
@@ -0,0 +1,736 @@
+package mikrotik
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/go-routeros/routeros"
+	"github.com/go-routeros/routeros/proto"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// mikrotikMenu declares a RouterOS menu, such as `/ip/address`, managed as a
+// terraform resource. The items of the menu are described by a struct whose
+// fields carry `mikrotik` tags (see Marshal). Create, Read, Update, Delete and
+// import are derived from those tags: the terraform attribute of a field is
+// its RouterOS attribute name with dashes replaced by underscores.
+//
+// The terraform schema is generated from the same tags. Besides the attribute
+// name the tag supports these options:
+//
+//	required   the attribute must be configured
+//	computed   the router picks a value when it is not configured
+//	readonly   the attribute is only read back from the router
+//	writeonly  the attribute is sent but never printed by the router
+//	move       the attribute is sent on add and applied to an existing item
+//	           with the `move` command, such as place-before
+//	set        the values of a list are unordered, such as the ports of a
+//	           bridge VLAN
+//	unordered  the router prints the comma separated values of a string in
+//	           an order of its own, such as connection states, so a plan
+//	           ignores their order
+//	sensitive  the attribute is a secret, such as a private key
+//	default=v  the value used when the attribute is not configured; as the
+//	           value may contain commas this must be the last option
+//
+// Any other attribute is optional. The fields of an embedded struct without
+// a tag are part of the item, see itemFields.
+type mikrotikMenu struct {
+	// path of the menu without a trailing slash
+	path string
+	// name used in log and error messages
+	name string
+	// item is a zero value of the struct describing an item of the menu
+	item interface{}
+	// legacy translates the attributes of menus which changed in RouterOS 7
+	// for older routers, optional
+	legacy *legacyAttributes
+}
+
+// legacyAttributes translates between the RouterOS 7 attributes of an item,
+// which the struct describes, and those of RouterOS 6.
+type legacyAttributes struct {
+	// send rewrites the attribute words of add and set commands. A value
+	// which RouterOS 6 expresses by the absence of the attribute is returned
+	// as the name of the attribute to unset instead.
+	send func(attributes []string) (sent, unset []string)
+	// print rewrites the attributes printed by the router, failing on an
+	// item the struct cannot describe
+	print func(attributes map[string]string) error
+	// names maps the attributes renamed in RouterOS 7 to their RouterOS 6
+	// name, for unset commands
+	names map[string]string
+}
+
+// isLegacy reports whether the attributes of the menu must be translated
+// for the router.
+func (menu *mikrotikMenu) isLegacy(c mikrotikConfig) (bool, error) {
+	if menu.legacy == nil {
+		return false, nil
+	}
+
+	major, err := c.majorVersion()
+	if err != nil {
+		return false, err
+	}
+	return major < 7, nil
+}
+
+// sendAttributes returns the attribute words to send to the router, and the
+// attributes to unset in their place, see legacyAttributes.send.
+func (menu *mikrotikMenu) sendAttributes(c mikrotikConfig, attributes []string) ([]string, []string, error) {
+	legacy, err := menu.isLegacy(c)
+	if err != nil || !legacy {
+		return attributes, nil, err
+	}
+	sent, unset := menu.legacy.send(attributes)
+	return sent, unset, nil
+}
+
+// sendName returns the name of an attribute to unset on the router.
+func (menu *mikrotikMenu) sendName(c mikrotikConfig, name string) (string, error) {
+	legacy, err := menu.isLegacy(c)
+	if err != nil || !legacy || menu.legacy.names[name] == "" {
+		return name, err
+	}
+	return menu.legacy.names[name], nil
+}
+
+// printedReply translates the sentences printed by the router in place.
+func (menu *mikrotikMenu) printedReply(c mikrotikConfig, r *routeros.Reply) error {
+	legacy, err := menu.isLegacy(c)
+	if err != nil || !legacy {
+		return err
+	}
+
+	for _, sentence := range r.Re {
+		attributes := map[string]string{}
+		for _, pair := range sentence.List {
+			attributes[pair.Key] = pair.Value
+		}
+		if err := menu.legacy.print(attributes); err != nil {
+			return err
+		}
+
+		keys := make([]string, 0, len(attributes))
+		for key := range attributes {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		sentence.List = sentence.List[:0]
+		for _, key := range keys {
+			sentence.List = append(sentence.List, proto.Pair{Key: key, Value: attributes[key]})
+		}
+		sentence.Map = attributes
+	}
+	return nil
+}
+
+// resource returns a terraform resource managing the items of the menu.
+// Resources needing more than the tags can express, such as validation,
+// adjust the returned schema.
+func (menu *mikrotikMenu) resource() *schema.Resource {
+	return &schema.Resource{
+		Create: menu.create,
+		Read:   menu.read,
+		Update: menu.update,
+		Delete: menu.delete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Timeouts: resourceTimeouts(),
+
+		Schema: menuSchema(menu.item),
+	}
+}
+
+func (menu *mikrotikMenu) newItem() interface{} {
+	return reflect.New(reflect.TypeOf(menu.item)).Interface()
+}
+
+func (menu *mikrotikMenu) create(d *schema.ResourceData, m interface{}) error {
+	c := m.(mikrotikConfig).withTimeout(d.Timeout(schema.TimeoutCreate))
+
+	item := menu.newItem()
+	dataToItem(d, item)
+
+	id, err := c.addItem(menu, item)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(id)
+	return menu.readItem(d, c)
+}
+
+func (menu *mikrotikMenu) read(d *schema.ResourceData, m interface{}) error {
+	c := m.(mikrotikConfig).withTimeout(d.Timeout(schema.TimeoutRead))
+
+	return menu.readItem(d, c)
+}
+
+func (menu *mikrotikMenu) readItem(d *schema.ResourceData, c mikrotikConfig) error {
+	item := menu.newItem()
+	err := c.findItem(menu, d.Id(), item)
+
+	if err != nil {
+		if _, ok := err.(*NotFound); ok {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	return itemToData(item, d)
+}
+
+func (menu *mikrotikMenu) update(d *schema.ResourceData, m interface{}) error {
+	c := m.(mikrotikConfig).withTimeout(d.Timeout(schema.TimeoutUpdate))
+
+	item := menu.newItem()
+	dataToItem(d, item)
+
+	attributes, unset := changedAttributes(d, item)
+	if err := c.setAttributes(menu, d.Id(), attributes); err != nil {
+		return err
+	}
+	if err := c.unsetAttributes(menu, d.Id(), unset); err != nil {
+		return err
+	}
+
+	for _, f := range itemFields(reflect.ValueOf(item)) {
+		if !contains(f.options, "move") || !d.HasChange(terraformName(f.name)) || f.value.String() == "" {
+			continue
+		}
+		if err := c.moveItem(menu, d.Id(), f.value.String()); err != nil {
+			return err
+		}
+	}
+
+	return menu.readItem(d, c)
+}
+
+func (menu *mikrotikMenu) delete(d *schema.ResourceData, m interface{}) error {
+	c := m.(mikrotikConfig).withTimeout(d.Timeout(schema.TimeoutDelete))
+
+	err := c.removeItem(menu, d.Id())
+	if err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// importByName is the importer of menus whose items have a unique name, such
+// as interfaces. The item may be imported by its name or by its id.
+func (menu *mikrotikMenu) importByName(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if strings.HasPrefix(d.Id(), "*") {
+		return []*schema.ResourceData{d}, nil
+	}
+
+	c := m.(mikrotikConfig).withTimeout(d.Timeout(schema.TimeoutRead))
+	items, err := c.listItems(menu, "?name="+d.Id())
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, NewNotFound(fmt.Sprintf("%s `%s` not found", menu.name, d.Id()))
+	}
+
+	d.SetId(itemId(items[0]))
+	return []*schema.ResourceData{d}, nil
+}
+
+// plannedNames records the names of the items planned in the current run,
+// per menu, shared by every copy of a mikrotikConfig. Terraform plans an item
+// before the resources referencing it, so requireExisting accepts the items
+// created in the same apply.
+type plannedNames struct {
+	mu    sync.Mutex
+	names map[string]map[string]bool
+}
+
+func (p *plannedNames) add(menu *mikrotikMenu, name string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.names == nil {
+		p.names = map[string]map[string]bool{}
+	}
+	if p.names[menu.path] == nil {
+		p.names[menu.path] = map[string]bool{}
+	}
+	p.names[menu.path][name] = true
+}
+
+func (p *plannedNames) has(menu *mikrotikMenu, name string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.names[menu.path][name]
+}
+
+// planName is the CustomizeDiff of menus whose items are referenced by name,
+// see requireExisting. It records the planned name of the item.
+func (menu *mikrotikMenu) planName(d *schema.ResourceDiff, m interface{}) error {
+	c, ok := m.(mikrotikConfig)
+	if !ok || !d.NewValueKnown("name") {
+		return nil
+	}
+
+	c.planned.add(menu, d.Get("name").(string))
+	return nil
+}
+
+// requireExisting returns a CustomizeDiff failing the plan when attribute
+// names an item of menu which neither exists on the router nor is planned
+// in the same run, rather than leaving the router to reject it on apply.
+func (menu *mikrotikMenu) requireExisting(attribute string) schema.CustomizeDiffFunc {
+	return func(d *schema.ResourceDiff, m interface{}) error {
+		c, ok := m.(mikrotikConfig)
+		if !ok || !d.NewValueKnown(attribute) || !d.HasChange(attribute) {
+			return nil
+		}
+
+		name := d.Get(attribute).(string)
+		if c.planned.has(menu, name) {
+			return nil
+		}
+
+		items, err := c.withTimeout(defaultResourceTimeout).listItems(menu, "?name="+name)
+		if err != nil {
+			return err
+		}
+		if len(items) == 0 {
+			return fmt.Errorf("%s references the %s `%s`, which does not exist", attribute, menu.name, name)
+		}
+		return nil
+	}
+}
+
+// addItem creates item in the menu and returns the id of the new item.
+func (mikrotikClient mikrotikConfig) addItem(menu *mikrotikMenu, item interface{}) (string, error) {
+	// a new item has nothing to unset
+	attributes, _, err := menu.sendAttributes(mikrotikClient, marshalAttributes(item))
+	if err != nil {
+		return "", err
+	}
+
+	cmd := []string{menu.path + "/add"}
+	cmd = append(cmd, attributes...)
+
+	r, err := mikrotikClient.Run(cmd)
+
+	log.Printf("[DEBUG] %s creation response: `%v`", menu.name, redactReply(r))
+
+	if err != nil {
+		return "", err
+	}
+
+	return r.Done.Map["ret"], nil
+}
+
+// findItem unmarshals the item with the given id into item, returning a
+// NotFound error if it does not exist.
+func (mikrotikClient mikrotikConfig) findItem(menu *mikrotikMenu, id string, item interface{}) error {
+	cmd := []string{menu.path + "/print", "?.id=" + id}
+	r, err := mikrotikClient.Run(cmd)
+
+	log.Printf("[DEBUG] %s response: %v", menu.name, redactReply(r))
+
+	if err != nil {
+		return err
+	}
+
+	if err := menu.printedReply(mikrotikClient, r); err != nil {
+		return err
+	}
+
+	err = Unmarshal(*r, item)
+
+	if err != nil {
+		return err
+	}
+
+	if itemId(item) == "" {
+		return NewNotFound(fmt.Sprintf("%s `%s` not found", menu.name, id))
+	}
+
+	return nil
+}
+
+// setItem sends every attribute of item which is set.
+func (mikrotikClient mikrotikConfig) setItem(menu *mikrotikMenu, id string, item interface{}) error {
+	return mikrotikClient.setAttributes(menu, id, marshalAttributes(item))
+}
+
+// setAttributes sends the given attribute words to the item with the given
+// id, skipping the command when there is nothing to change.
+func (mikrotikClient mikrotikConfig) setAttributes(menu *mikrotikMenu, id string, attributes []string) error {
+	if len(attributes) == 0 {
+		return nil
+	}
+
+	attributes, unset, err := menu.sendAttributes(mikrotikClient, attributes)
+	if err != nil {
+		return err
+	}
+
+	if len(attributes) > 0 {
+		cmd := []string{menu.path + "/set", "=.id=" + id}
+		cmd = append(cmd, attributes...)
+
+		r, err := mikrotikClient.Run(cmd)
+
+		log.Printf("[DEBUG] %s update response: `%v`", menu.name, redactReply(r))
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return mikrotikClient.unsetAttributes(menu, id, unset)
+}
+
+// unsetAttributes resets the named attributes of the item with the given id
+// to their default, with one unset command per attribute.
+func (mikrotikClient mikrotikConfig) unsetAttributes(menu *mikrotikMenu, id string, names []string) error {
+	for _, name := range names {
+		name, err := menu.sendName(mikrotikClient, name)
+		if err != nil {
+			return err
+		}
+		cmd := []string{menu.path + "/unset", "=numbers=" + id, "=value-name=" + name}
+
+		r, err := mikrotikClient.Run(cmd)
+
+		log.Printf("[DEBUG] %s unset response: `%v`", menu.name, redactReply(r))
+
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (mikrotikClient mikrotikConfig) removeItem(menu *mikrotikMenu, id string) error {
+	cmd := []string{menu.path + "/remove", "=.id=" + id}
+
+	r, err := mikrotikClient.Run(cmd)
+
+	log.Printf("[DEBUG] %s delete response: `%v`", menu.name, redactReply(r))
+
+	return err
+}
+
+// itemId returns the value of the field tagged `.id`.
+func itemId(item interface{}) string {
+	if field := itemField(item, ".id"); field.IsValid() {
+		return field.String()
+	}
+	return ""
+}
+
+// itemField returns the field of item tagged with the attribute name, or the
+// zero Value if there is none.
+func itemField(item interface{}, name string) reflect.Value {
+	for _, field := range itemFields(reflect.ValueOf(item)) {
+		if field.name == name {
+			return field.value
+		}
+	}
+	return reflect.Value{}
+}
+
+// mikrotikField is a field of an item tagged with a RouterOS attribute.
+type mikrotikField struct {
+	name    string
+	options []string
+	field   reflect.StructField
+	value   reflect.Value
+}
+
+// itemFields returns the tagged fields of the struct v, or the struct v
+// points to, in declaration order. The fields of embedded untagged structs
+// are included in place, which lets menus share groups of attributes such
+// as the firewall matchers.
+func itemFields(v reflect.Value) []mikrotikField {
+	elem := reflect.Indirect(v)
+
+	var fields []mikrotikField
+	for i := 0; i < elem.NumField(); i++ {
+		field := elem.Type().Field(i)
+		if isEmbeddedStruct(field) {
+			fields = append(fields, itemFields(elem.Field(i))...)
+			continue
+		}
+
+		name, options := mikrotikTag(field)
+		if name == "" {
+			continue
+		}
+		fields = append(fields, mikrotikField{name, options, field, elem.Field(i)})
+	}
+	return fields
+}
+
+// typeFields returns the tagged fields of the struct type t, see itemFields.
+func typeFields(t reflect.Type) []mikrotikField {
+	return itemFields(reflect.New(t).Elem())
+}
+
+func isEmbeddedStruct(field reflect.StructField) bool {
+	return field.Anonymous && field.Type.Kind() == reflect.Struct && field.Tag.Get("mikrotik") == ""
+}
+
+// mikrotikTag splits the `mikrotik` tag of a field into the attribute name
+// and its options.
+func mikrotikTag(field reflect.StructField) (string, []string) {
+	tags := strings.Split(field.Tag.Get("mikrotik"), ",")
+	for i, option := range tags {
+		if strings.HasPrefix(option, "default=") {
+			tags = append(tags[:i], strings.Join(tags[i:], ","))
+			break
+		}
+	}
+	return tags[0], tags[1:]
+}
+
+// menuSchema builds the terraform schema of every tagged attribute of item.
+// It panics on a tag it cannot translate, as that is a programming error
+// caught by the schema tests.
+func menuSchema(item interface{}) map[string]*schema.Schema {
+	s := map[string]*schema.Schema{}
+
+	t := reflect.TypeOf(item)
+	for _, f := range typeFields(t) {
+		field, name, options := f.field, f.name, f.options
+		if name == ".id" {
+			continue
+		}
+
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+
+		attribute := &schema.Schema{}
+		switch fieldType.Kind() {
+		case reflect.String:
+			attribute.Type = schema.TypeString
+		case reflect.Bool:
+			attribute.Type = schema.TypeBool
+		case reflect.Int:
+			attribute.Type = schema.TypeInt
+		case reflect.Slice:
+			if fieldType.Elem().Kind() != reflect.String {
+				panic(fmt.Sprintf("unsupported type %s of %s.%s", field.Type, t.Name(), field.Name))
+			}
+			attribute.Type = schema.TypeList
+			attribute.Elem = &schema.Schema{Type: schema.TypeString}
+			if contains(options, "set") {
+				attribute.Type = schema.TypeSet
+				attribute.Set = schema.HashString
+			}
+		default:
+			panic(fmt.Sprintf("unsupported type %s of %s.%s", field.Type, t.Name(), field.Name))
+		}
+
+		for _, option := range options {
+			switch {
+			case option == "required":
+				attribute.Required = true
+			case option == "computed":
+				attribute.Computed = true
+			case option == "readonly":
+				attribute.Computed = true
+			case option == "sensitive":
+				attribute.Sensitive = true
+			case option == "unordered":
+				attribute.DiffSuppressFunc = suppressReorderedList
+			case strings.HasPrefix(option, "default="):
+				attribute.Default = tagDefault(field.Name, fieldType, strings.TrimPrefix(option, "default="))
+			}
+		}
+		attribute.Optional = !attribute.Required && !contains(options, "readonly")
+
+		s[terraformName(name)] = attribute
+	}
+
+	return s
+}
+
+// suppressReorderedList suppresses the diff of an unordered attribute whose
+// values only changed their order.
+func suppressReorderedList(k, old, new string, d *schema.ResourceData) bool {
+	return sortedList(old) == sortedList(new)
+}
+
+// sortedList sorts the comma separated values of value.
+func sortedList(value string) string {
+	list := strings.Split(value, ",")
+	sort.Strings(list)
+	return strings.Join(list, ",")
+}
+
+func tagDefault(name string, t reflect.Type, value string) interface{} {
+	switch t.Kind() {
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			panic(fmt.Sprintf("invalid default %q of %s: %v", value, name, err))
+		}
+		return b
+	case reflect.Int:
+		i, err := strconv.Atoi(value)
+		if err != nil {
+			panic(fmt.Sprintf("invalid default %q of %s: %v", value, name, err))
+		}
+		return i
+	}
+	return value
+}
+
+// terraformName is the terraform attribute for a RouterOS attribute.
+func terraformName(name string) string {
+	return strings.Replace(name, "-", "_", -1)
+}
+
+// dataToItem copies the configuration of every tagged attribute, except
+// the readonly ones, into item. Pointer fields are left nil when the
+// attribute is not configured, so Marshal does not send them. Note the SDK
+// reports a bool removed from the configuration as false rather than unset.
+func dataToItem(d *schema.ResourceData, item interface{}) {
+	for _, f := range itemFields(reflect.ValueOf(item)) {
+		field := f.value
+		if f.name == ".id" || contains(f.options, "readonly") {
+			continue
+		}
+
+		key := terraformName(f.name)
+		if field.Kind() == reflect.Ptr {
+			if _, ok := d.GetOkExists(key); !ok {
+				field.Set(reflect.Zero(field.Type()))
+				continue
+			}
+			field.Set(reflect.New(field.Type().Elem()))
+			field = field.Elem()
+		}
+
+		field.Set(convertValue(d.Get(key), field.Type()))
+	}
+}
+
+// convertValue converts a terraform value to the type of a field. Lists
+// and sets become string slices, which are nil when empty as after
+// Unmarshal. Sets are sorted, rather than kept in the order of their hashes.
+func convertValue(value interface{}, t reflect.Type) reflect.Value {
+	if set, ok := value.(*schema.Set); ok {
+		list := set.List()
+		sort.Slice(list, func(i, j int) bool {
+			return fmt.Sprint(list[i]) < fmt.Sprint(list[j])
+		})
+		value = list
+	}
+	if list, ok := value.([]interface{}); ok {
+		slice := reflect.Zero(t)
+		for _, e := range list {
+			slice = reflect.Append(slice, reflect.ValueOf(e).Convert(t.Elem()))
+		}
+		return slice
+	}
+	return reflect.ValueOf(value).Convert(t)
+}
+
+// changedAttributes returns the attribute words of item for the attributes
+// changed in the terraform diff, and the names of the attributes removed from
+// the configuration. The router rejects an empty value for most typed
+// attributes, so removed attributes are unset rather than sent empty, except
+// for flags which are sent as no. Attributes tagged move are left out as
+// `set` does not accept them.
+func changedAttributes(d *schema.ResourceData, item interface{}) (attributes, unset []string) {
+	for _, f := range itemFields(reflect.ValueOf(item)) {
+		if f.name == ".id" || contains(f.options, "readonly") || contains(f.options, "move") || !d.HasChange(terraformName(f.name)) {
+			continue
+		}
+
+		value := f.value
+		if value.Kind() == reflect.Ptr && value.IsNil() && value.Type().Elem().Kind() == reflect.Bool {
+			value = reflect.ValueOf(false)
+		}
+
+		if word, ok := marshalAttribute(f.name, value); ok && !(value.Kind() == reflect.Slice && value.Len() == 0) {
+			attributes = append(attributes, word)
+		} else {
+			unset = append(unset, f.name)
+		}
+	}
+
+	return attributes, unset
+}
+
+// itemToData writes every tagged attribute of item, except the writeonly
+// ones, to the terraform state and sets the id.
+func itemToData(item interface{}, d *schema.ResourceData) error {
+	d.SetId(itemId(item))
+
+	for key, value := range itemValues(item) {
+		if err := d.Set(key, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// itemValues returns the terraform value of every tagged attribute of item
+// except the writeonly ones. Unset pointers have the zero value.
+func itemValues(item interface{}) map[string]interface{} {
+	values := map[string]interface{}{}
+
+	for _, f := range itemFields(reflect.ValueOf(item)) {
+		if f.name == ".id" || contains(f.options, "writeonly") {
+			continue
+		}
+
+		value := f.value
+		if value.Kind() == reflect.Ptr {
+			if value.IsNil() {
+				value = reflect.Zero(value.Type().Elem())
+			} else {
+				value = value.Elem()
+			}
+		}
+
+		values[terraformName(f.name)] = value.Interface()
+	}
+	return values
+}
+
+// valuesToItem is the inverse of itemValues for the attributes of nested
+// blocks, where unset and zero cannot be told apart: pointer fields are left
+// nil for zero values.
+func valuesToItem(values map[string]interface{}, item interface{}) {
+	for _, f := range itemFields(reflect.ValueOf(item)) {
+		field := f.value
+		value, ok := values[terraformName(f.name)]
+		if f.name == ".id" || contains(f.options, "readonly") || !ok {
+			continue
+		}
+
+		if field.Kind() == reflect.Ptr {
+			if reflect.ValueOf(value).IsZero() {
+				continue
+			}
+			field.Set(reflect.New(field.Type().Elem()))
+			field = field.Elem()
+		}
+		field.Set(convertValue(value, field.Type()))
+	}
+}
@@ -0,0 +1,372 @@
+package mikrotik
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// rulesResource returns a terraform resource owning every rule of one or more
+// chains of an ordered menu, such as `/ip/firewall/filter`. The rules are
+// declared as an ordered list of `rule` blocks with the attributes of the
+// menu item. Rules on the router are matched to the declared ones by their
+// attributes: missing rules are added, all of them moved until they are in
+// the declared order and the rules left unmatched removed. Dynamic rules are
+// left alone.
+//
+// The id of the resource is the sorted, comma separated list of chains,
+// which is also what is expected on import.
+func (menu *mikrotikMenu) rulesResource() *schema.Resource {
+	rule := menuSchema(menu.item)
+	for _, f := range typeFields(reflect.TypeOf(menu.item)) {
+		if contains(f.options, "readonly") || contains(f.options, "writeonly") {
+			delete(rule, terraformName(f.name))
+		}
+	}
+
+	return &schema.Resource{
+		Create: menu.createRules,
+		Read:   menu.readRules,
+		Update: menu.updateRules,
+		Delete: menu.deleteRules,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Timeouts: resourceTimeouts(),
+
+		Schema: map[string]*schema.Schema{
+			"chains": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"rule": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Resource{Schema: rule},
+			},
+		},
+	}
+}
+
+func (menu *mikrotikMenu) createRules(d *schema.ResourceData, m interface{}) error {
+	c := m.(mikrotikConfig).withTimeout(d.Timeout(schema.TimeoutCreate))
+
+	chains := d.Get("chains").(*schema.Set)
+	if err := menu.syncRules(d, c, chains); err != nil {
+		return err
+	}
+
+	d.SetId(rulesId(chains))
+	return menu.readRulesOf(d, c)
+}
+
+func (menu *mikrotikMenu) readRules(d *schema.ResourceData, m interface{}) error {
+	c := m.(mikrotikConfig).withTimeout(d.Timeout(schema.TimeoutRead))
+
+	return menu.readRulesOf(d, c)
+}
+
+func (menu *mikrotikMenu) readRulesOf(d *schema.ResourceData, c mikrotikConfig) error {
+	chains := strings.Split(d.Id(), ",")
+
+	current, err := menu.ownedRules(c, schema.NewSet(schema.HashString, stringsToInterfaces(chains)))
+	if err != nil {
+		return err
+	}
+
+	// a rule printed the way the router normalizes it keeps the values of
+	// the configuration, so it does not show up as a change
+	configured := d.Get("rule").([]interface{})
+	rules := make([]interface{}, len(current))
+	for i, item := range current {
+		values := menu.ruleValues(item)
+		if i < len(configured) {
+			known := menu.newItem()
+			valuesToItem(configured[i].(map[string]interface{}), known)
+			if knownValues := menu.ruleValues(known); menu.sameRule(knownValues, values) {
+				values = knownValues
+			}
+		}
+		rules[i] = values
+	}
+
+	if err := d.Set("chains", chains); err != nil {
+		return err
+	}
+	return d.Set("rule", rules)
+}
+
+func (menu *mikrotikMenu) updateRules(d *schema.ResourceData, m interface{}) error {
+	c := m.(mikrotikConfig).withTimeout(d.Timeout(schema.TimeoutUpdate))
+
+	// rules of chains dropped from the resource are removed as well
+	old, chains := d.GetChange("chains")
+	owned := old.(*schema.Set).Union(chains.(*schema.Set))
+	if err := menu.syncRules(d, c, owned); err != nil {
+		return err
+	}
+
+	d.SetId(rulesId(chains.(*schema.Set)))
+	return menu.readRulesOf(d, c)
+}
+
+func (menu *mikrotikMenu) deleteRules(d *schema.ResourceData, m interface{}) error {
+	c := m.(mikrotikConfig).withTimeout(d.Timeout(schema.TimeoutDelete))
+
+	chains := strings.Split(d.Id(), ",")
+	current, err := menu.ownedRules(c, schema.NewSet(schema.HashString, stringsToInterfaces(chains)))
+	if err != nil {
+		return err
+	}
+
+	if len(current) > 0 {
+		ids := make([]string, len(current))
+		for i, item := range current {
+			ids[i] = itemId(item)
+		}
+		if err := c.removeItem(menu, strings.Join(ids, ",")); err != nil {
+			return err
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// syncRules converges the rules of the owned chains to the declared ones.
+func (menu *mikrotikMenu) syncRules(d *schema.ResourceData, c mikrotikConfig, owned *schema.Set) error {
+	chains := d.Get("chains").(*schema.Set)
+
+	var desired []interface{}
+	for i, values := range d.Get("rule").([]interface{}) {
+		item := menu.newItem()
+		valuesToItem(values.(map[string]interface{}), item)
+
+		if chain := itemField(item, "chain").String(); !chains.Contains(chain) {
+			return fmt.Errorf("rule %d is in chain `%s` which is not one of the chains of the resource", i, chain)
+		}
+		desired = append(desired, item)
+	}
+
+	current, err := menu.ownedRules(c, owned)
+	if err != nil {
+		return err
+	}
+
+	// match every declared rule to the first identical rule on the router
+	matched := make([]bool, len(current))
+	ids := make([]string, len(desired))
+	for i, item := range desired {
+		values := menu.ruleValues(item)
+		for j, existing := range current {
+			if !matched[j] && menu.sameRule(values, menu.ruleValues(existing)) {
+				matched[j] = true
+				ids[i] = itemId(existing)
+				break
+			}
+		}
+	}
+
+	var order, unmatched []string
+	for j, existing := range current {
+		if matched[j] {
+			order = append(order, itemId(existing))
+		} else {
+			unmatched = append(unmatched, itemId(existing))
+		}
+	}
+
+	for i, item := range desired {
+		if ids[i] != "" {
+			continue
+		}
+		id, err := c.addItem(menu, item)
+		if err != nil {
+			return err
+		}
+		ids[i] = id
+		order = append(order, id)
+	}
+
+	// working backwards, move every rule which does not directly precede
+	// its successor in front of it
+	for i := len(ids) - 2; i >= 0; i-- {
+		position := indexOf(order, ids[i])
+		next := indexOf(order, ids[i+1])
+		if position == next-1 {
+			continue
+		}
+
+		if err := c.moveItem(menu, ids[i], ids[i+1]); err != nil {
+			return err
+		}
+		order = append(order[:position], order[position+1:]...)
+		next = indexOf(order, ids[i+1])
+		order = append(order[:next], append([]string{ids[i]}, order[next:]...)...)
+	}
+
+	// stale rules are removed last so the chains are never left without
+	// the rules they keep
+	if len(unmatched) > 0 {
+		return c.removeItem(menu, strings.Join(unmatched, ","))
+	}
+
+	return nil
+}
+
+// ownedRules returns the static rules of the given chains in router order.
+func (menu *mikrotikMenu) ownedRules(c mikrotikConfig, chains *schema.Set) ([]interface{}, error) {
+	items, err := c.listItems(menu)
+	if err != nil {
+		return nil, err
+	}
+
+	var owned []interface{}
+	for _, item := range items {
+		if dynamic := itemField(item, "dynamic"); dynamic.IsValid() && dynamic.Bool() {
+			continue
+		}
+		if chains.Contains(itemField(item, "chain").String()) {
+			owned = append(owned, item)
+		}
+	}
+	return owned, nil
+}
+
+// ruleValues returns the values of a rule as configured in a rule block.
+func (menu *mikrotikMenu) ruleValues(item interface{}) map[string]interface{} {
+	values := itemValues(item)
+
+	for _, f := range typeFields(reflect.TypeOf(menu.item)) {
+		if contains(f.options, "readonly") {
+			delete(values, terraformName(f.name))
+		}
+	}
+	return values
+}
+
+// sameRule reports whether the rule values a and b are the same once both
+// are normalized the way the router prints them: attributes left empty take
+// their default, the host prefix of a single address is dropped and the
+// entries of unordered lists are sorted.
+func (menu *mikrotikMenu) sameRule(a, b map[string]interface{}) bool {
+	return reflect.DeepEqual(menu.normalizedRule(a), menu.normalizedRule(b))
+}
+
+func (menu *mikrotikMenu) normalizedRule(values map[string]interface{}) map[string]interface{} {
+	normalized := map[string]interface{}{}
+	for key, value := range values {
+		normalized[key] = value
+	}
+
+	for _, f := range typeFields(reflect.TypeOf(menu.item)) {
+		key := terraformName(f.name)
+		value, ok := normalized[key].(string)
+		if !ok {
+			continue
+		}
+
+		for _, option := range f.options {
+			if strings.HasPrefix(option, "default=") && value == "" {
+				value = strings.TrimPrefix(option, "default=")
+			}
+		}
+		value = withoutHostPrefix(value)
+		if contains(f.options, "unordered") {
+			value = sortedList(value)
+		}
+		normalized[key] = value
+	}
+	return normalized
+}
+
+// withoutHostPrefix drops the /32 or /128 of a single address, which the
+// router does not print, keeping any leading `!` negating it.
+func withoutHostPrefix(value string) string {
+	negation := ""
+	if strings.HasPrefix(value, "!") {
+		negation, value = "!", value[1:]
+	}
+
+	ip, network, err := net.ParseCIDR(value)
+	if err != nil || !ip.Equal(network.IP) {
+		return negation + value
+	}
+	if ones, bits := network.Mask.Size(); ones != bits {
+		return negation + value
+	}
+	return negation + ip.String()
+}
+
+// listItems returns the items of the menu matching every query word, such as
+// `?list=blocklist`, in router order.
+func (mikrotikClient mikrotikConfig) listItems(menu *mikrotikMenu, queries ...string) ([]interface{}, error) {
+	cmd := append([]string{menu.path + "/print"}, queries...)
+	r, err := mikrotikClient.Run(cmd)
+
+	log.Printf("[DEBUG] %s list response: %v", menu.name, redactReply(r))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := menu.printedReply(mikrotikClient, r); err != nil {
+		return nil, err
+	}
+
+	list := reflect.New(reflect.SliceOf(reflect.PtrTo(reflect.TypeOf(menu.item))))
+	if err := Unmarshal(*r, list.Interface()); err != nil {
+		return nil, err
+	}
+
+	items := make([]interface{}, list.Elem().Len())
+	for i := range items {
+		items[i] = list.Elem().Index(i).Interface()
+	}
+	return items, nil
+}
+
+// moveItem moves the item with the given id in front of destination.
+func (mikrotikClient mikrotikConfig) moveItem(menu *mikrotikMenu, id, destination string) error {
+	cmd := []string{menu.path + "/move", "=numbers=" + id, "=destination=" + destination}
+
+	r, err := mikrotikClient.Run(cmd)
+
+	log.Printf("[DEBUG] %s move response: `%v`", menu.name, redactReply(r))
+
+	return err
+}
+
+// rulesId returns the id of a rules resource owning chains.
+func rulesId(chains *schema.Set) string {
+	var names []string
+	for _, chain := range chains.List() {
+		names = append(names, chain.(string))
+	}
+	sort.Strings(names)
+	return strings.Join(names, ",")
+}
+
+func indexOf(s []string, e string) int {
+	for i, a := range s {
+		if a == e {
+			return i
+		}
+	}
+	return -1
+}
+
+func stringsToInterfaces(s []string) []interface{} {
+	result := make([]interface{}, len(s))
+	for i, v := range s {
+		result[i] = v
+	}
+	return result
+}
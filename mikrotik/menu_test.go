@@ -0,0 +1,293 @@
+package mikrotik
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+type testMenuItem struct {
+	Id       string   `mikrotik:".id"`
+	Name     string   `mikrotik:"name"`
+	RunCount int      `mikrotik:"run-count"`
+	Disabled bool     `mikrotik:"disabled"`
+	Running  string   `mikrotik:"running,readonly"`
+	CopyFrom string   `mikrotik:"copy-from,writeonly"`
+	Ports    []string `mikrotik:"ports"`
+}
+
+var testMenuSchema = map[string]*schema.Schema{
+	"name":      {Type: schema.TypeString, Required: true},
+	"run_count": {Type: schema.TypeInt, Optional: true},
+	"disabled":  {Type: schema.TypeBool, Optional: true},
+	"running":   {Type: schema.TypeString, Computed: true},
+	"copy_from": {Type: schema.TypeString, Optional: true},
+	"ports":     {Type: schema.TypeList, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+}
+
+func TestMikrotikProvider_DataToItem(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, testMenuSchema, map[string]interface{}{
+		"name":      "test",
+		"run_count": 3,
+		"disabled":  true,
+		"copy_from": "other",
+		"ports":     []interface{}{"80", "443"},
+	})
+
+	item := &testMenuItem{}
+	dataToItem(d, item)
+
+	expected := &testMenuItem{Name: "test", RunCount: 3, Disabled: true, CopyFrom: "other", Ports: []string{"80", "443"}}
+	if !reflect.DeepEqual(item, expected) {
+		t.Errorf("dataToItem returned %v, expected %v", item, expected)
+	}
+
+	attributes := marshalAttributes(item)
+	expectedAttributes := []string{"=name=test", "=run-count=3", "=disabled=yes", "=copy-from=other", "=ports=80,443"}
+	if !reflect.DeepEqual(attributes, expectedAttributes) {
+		t.Errorf("marshalAttributes returned %v, expected %v", attributes, expectedAttributes)
+	}
+}
+
+func TestMikrotikProvider_ItemToData(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, testMenuSchema, map[string]interface{}{
+		"copy_from": "other",
+	})
+
+	item := &testMenuItem{Id: "*1", Name: "test", RunCount: 3, Running: "true"}
+	if err := itemToData(item, d); err != nil {
+		t.Fatalf("itemToData failed with: %v", err)
+	}
+
+	if d.Id() != "*1" {
+		t.Errorf("Expected id *1, received %s", d.Id())
+	}
+	if d.Get("name") != "test" || d.Get("run_count") != 3 || d.Get("running") != "true" {
+		t.Errorf("Unexpected state name=%v run_count=%v running=%v", d.Get("name"), d.Get("run_count"), d.Get("running"))
+	}
+	if d.Get("copy_from") != "other" {
+		t.Errorf("A writeonly attribute should keep its configured value, received %v", d.Get("copy_from"))
+	}
+}
+
+// testMenuResources maps every resource of the provider to the menu it is
+// generated from, so the drift test covers all of them.
+var testMenuResources = map[string]*mikrotikMenu{
+	"mikrotik_interface_6to4":             interface6to4Menu,
+	"mikrotik_interface_bridge":           interfaceBridgeMenu,
+	"mikrotik_interface_bridge_port":      interfaceBridgePortMenu,
+	"mikrotik_interface_bridge_vlan":      interfaceBridgeVlanMenu,
+	"mikrotik_interface_eoip":             interfaceEoipMenu,
+	"mikrotik_interface_gre":              interfaceGreMenu,
+	"mikrotik_interface_ipip":             interfaceIpipMenu,
+	"mikrotik_interface_list":             interfaceListMenu,
+	"mikrotik_interface_list_member":      interfaceListMemberMenu,
+	"mikrotik_interface_vlan":             interfaceVlanMenu,
+	"mikrotik_interface_wireguard":        interfaceWireguardMenu,
+	"mikrotik_interface_wireguard_peer":   interfaceWireguardPeerMenu,
+	"mikrotik_ip_address":                 ipAddressMenu,
+	"mikrotik_ip_firewall_address_list":   ipFirewallAddressListMenu,
+	"mikrotik_ip_firewall_filter":         ipFirewallFilterMenu,
+	"mikrotik_ip_firewall_mangle":         ipFirewallMangleMenu,
+	"mikrotik_ip_firewall_nat":            ipFirewallNatMenu,
+	"mikrotik_ip_firewall_raw":            ipFirewallRawMenu,
+	"mikrotik_ip_route":                   ipRouteMenu,
+	"mikrotik_ipv6_address":               ipv6AddressMenu,
+	"mikrotik_ipv6_firewall_address_list": ipv6FirewallAddressListMenu,
+	"mikrotik_ipv6_firewall_filter":       ipv6FirewallFilterMenu,
+	"mikrotik_ipv6_firewall_mangle":       ipv6FirewallMangleMenu,
+	"mikrotik_ipv6_firewall_nat":          ipv6FirewallNatMenu,
+	"mikrotik_ipv6_route":                 ipv6RouteMenu,
+}
+
+func TestMikrotikProvider_MenuSchema(t *testing.T) {
+	type item struct {
+		Id        string   `mikrotik:".id"`
+		Name      string   `mikrotik:"name,required"`
+		Keepalive string   `mikrotik:"keepalive,default=10s,10"`
+		Mtu       int      `mikrotik:"mtu,computed"`
+		Running   bool     `mikrotik:"running,readonly"`
+		Gateways  []string `mikrotik:"gateway"`
+		Tagged    []string `mikrotik:"tagged,set"`
+		Secret    string   `mikrotik:"secret,sensitive"`
+		Untagged  string
+	}
+
+	s := menuSchema(item{})
+	expected := map[string]*schema.Schema{
+		"name":      {Type: schema.TypeString, Required: true},
+		"keepalive": {Type: schema.TypeString, Optional: true, Default: "10s,10"},
+		"mtu":       {Type: schema.TypeInt, Optional: true, Computed: true},
+		"running":   {Type: schema.TypeBool, Computed: true},
+		"gateway":   {Type: schema.TypeList, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+		"tagged":    {Type: schema.TypeSet, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+		"secret":    {Type: schema.TypeString, Optional: true, Sensitive: true},
+	}
+	// functions are never deeply equal
+	if s["tagged"].Set == nil {
+		t.Error("menuSchema should hash the values of a set")
+	}
+	s["tagged"].Set = nil
+	if !reflect.DeepEqual(s, expected) {
+		t.Errorf("menuSchema returned %v, expected %v", s, expected)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("menuSchema should panic on a default not matching the field type")
+		}
+	}()
+	menuSchema(struct {
+		Disabled bool `mikrotik:"disabled,default=maybe"`
+	}{})
+}
+
+// testRulesResources maps the resources managing whole chains to the menu of
+// their rule blocks.
+var testRulesResources = map[string]*mikrotikMenu{
+	"mikrotik_ip_firewall_filter_rules": ipFirewallFilterMenu,
+}
+
+// testCustomResources lists the resources whose schema is written by hand
+// rather than generated from a menu.
+var testCustomResources = map[string]bool{
+	"mikrotik_ip_firewall_address_list_set": true,
+}
+
+// TestMikrotikProvider_ResourceSchemaDrift fails when a resource schema
+// no longer matches the tags of the struct its menu is built from.
+func TestMikrotikProvider_ResourceSchemaDrift(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	if err := provider.InternalValidate(); err != nil {
+		t.Fatalf("The provider schema is invalid: %v", err)
+	}
+
+	for name, r := range provider.ResourcesMap {
+		if menu, ok := testMenuResources[name]; ok {
+			testSchemaDrift(t, name, menu, r.Schema, false)
+			continue
+		}
+		if menu, ok := testRulesResources[name]; ok {
+			rule := r.Schema["rule"].Elem.(*schema.Resource)
+			testSchemaDrift(t, name, menu, rule.Schema, true)
+			continue
+		}
+		if testCustomResources[name] {
+			continue
+		}
+		t.Errorf("%s is not listed in testMenuResources, testRulesResources or testCustomResources", name)
+	}
+}
+
+// testSchemaDrift compares s with the tags of the menu item. Rule blocks
+// leave out the readonly and writeonly attributes.
+func testSchemaDrift(t *testing.T, name string, menu *mikrotikMenu, s map[string]*schema.Schema, rule bool) {
+	kinds := map[reflect.Kind]schema.ValueType{
+		reflect.String: schema.TypeString,
+		reflect.Bool:   schema.TypeBool,
+		reflect.Int:    schema.TypeInt,
+		reflect.Slice:  schema.TypeList,
+	}
+
+	fields := map[string]bool{}
+	itemType := reflect.TypeOf(menu.item)
+	for _, f := range typeFields(itemType) {
+		field, tag, options := f.field, f.name, f.options
+		if tag == ".id" {
+			continue
+		}
+		if rule && (contains(options, "readonly") || contains(options, "writeonly")) {
+			continue
+		}
+		attribute := terraformName(tag)
+		fields[attribute] = true
+
+		a, ok := s[attribute]
+		if !ok {
+			t.Errorf("%s: %s.%s has no %s attribute", name, itemType.Name(), field.Name, attribute)
+			continue
+		}
+		kind := field.Type.Kind()
+		if kind == reflect.Ptr {
+			kind = field.Type.Elem().Kind()
+		}
+		expected := kinds[kind]
+		if contains(options, "set") {
+			expected = schema.TypeSet
+		}
+		if a.Type != expected {
+			t.Errorf("%s: %s is a %s but %s.%s is a %s", name, attribute, a.Type, itemType.Name(), field.Name, field.Type)
+		}
+		if contains(options, "readonly") && (a.Optional || a.Required || !a.Computed) {
+			t.Errorf("%s: the readonly attribute %s should only be computed", name, attribute)
+		}
+		if contains(options, "sensitive") != a.Sensitive {
+			t.Errorf("%s: %s is sensitive in only one of the schema and the tag", name, attribute)
+		}
+		if contains(options, "sensitive") && !secretAttributes[tag] {
+			t.Errorf("%s: the sensitive attribute %s is not redacted from the log, see secretAttributes", name, tag)
+		}
+		if secretAttributes[tag] && !a.Sensitive {
+			t.Errorf("%s: the secret attribute %s should be tagged sensitive", name, attribute)
+		}
+		if contains(options, "required") != a.Required {
+			t.Errorf("%s: %s is required in only one of the schema and the tag", name, attribute)
+		}
+		if contains(options, "unordered") && a.DiffSuppressFunc == nil {
+			t.Errorf("%s: the unordered attribute %s should suppress reordered values", name, attribute)
+		}
+	}
+
+	for attribute := range s {
+		if !fields[attribute] {
+			t.Errorf("%s: %s has no matching field in %s", name, attribute, itemType.Name())
+		}
+	}
+}
+
+// testAccCheckMenuItemExists returns a check that the item of resourceName
+// exists on the router.
+func testAccCheckMenuItemExists(menu *mikrotikMenu, resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resourceName)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("%s does not exist in the statefile", resourceName)
+		}
+
+		c := NewClient(GetConfigFromEnv())
+		if err := c.findItem(menu, rs.Primary.ID, menu.newItem()); err != nil {
+			return fmt.Errorf("Unable to get the %s with error: %v", menu.name, err)
+		}
+		return nil
+	}
+}
+
+// testAccCheckMenuItemDestroy returns a CheckDestroy function verifying the
+// items of every resource of resourceType are gone from the router.
+func testAccCheckMenuItemDestroy(menu *mikrotikMenu, resourceType string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		c := NewClient(GetConfigFromEnv())
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != resourceType {
+				continue
+			}
+
+			err := c.findItem(menu, rs.Primary.ID, menu.newItem())
+			if err == nil {
+				return fmt.Errorf("%s (%s) still exists", menu.name, rs.Primary.ID)
+			}
+			if _, ok := err.(*NotFound); !ok {
+				return err
+			}
+		}
+		return nil
+	}
+}
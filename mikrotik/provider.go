@@ -1,10 +1,13 @@
 package mikrotik
 
 import (
-	"errors"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"io/ioutil"
 	"log"
 	"math"
+	"net"
 	"os"
 	"reflect"
 	"strconv"
@@ -14,11 +17,12 @@ import (
 	"github.com/go-routeros/routeros"
 	"github.com/go-routeros/routeros/proto"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 )
 
 func Provider() terraform.ResourceProvider {
-	return &schema.Provider{
+	provider := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"host": {
 				Type:        schema.TypeString,
@@ -35,18 +39,118 @@ func Provider() terraform.ResourceProvider {
 			"password": {
 				Type:        schema.TypeString,
 				Required:    true,
+				Sensitive:   true,
 				DefaultFunc: schema.EnvDefaultFunc("MIKROTIK_PASSWORD", nil),
 				Description: "Password for mikrotik api",
 			},
+			"tls": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("MIKROTIK_TLS", false),
+				Description: "Whether to connect to the API-SSL service (usually port 8729)",
+			},
+			"ca_certificate": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("MIKROTIK_CA_CERTIFICATE", ""),
+				Description: "Path to a PEM encoded CA certificate used to verify the router certificate",
+			},
+			"insecure": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("MIKROTIK_INSECURE", false),
+				Description: "Skip verification of the router certificate",
+			},
+			"client_certificate": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("MIKROTIK_CLIENT_CERTIFICATE", ""),
+				Description: "Path to a PEM encoded client certificate presented to the router",
+			},
+			"client_key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("MIKROTIK_CLIENT_KEY", ""),
+				Description: "Path to the PEM encoded private key of the client certificate",
+			},
+			"connect_timeout": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("MIKROTIK_CONNECT_TIMEOUT", defaultConnectTimeout.String()),
+				Description: "Maximum time to wait while connecting and logging in to the router",
+			},
+			"command_timeout": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("MIKROTIK_COMMAND_TIMEOUT", defaultCommandTimeout.String()),
+				Description: "Maximum time to wait for the reply to a single command, 0s waits forever",
+			},
+			"max_retries": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("MIKROTIK_MAX_RETRIES", defaultMaxRetries),
+				Description: "Number of times a command failing with a retryable error is retried",
+			},
+			"transport": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("MIKROTIK_TRANSPORT", apiTransportName),
+				ValidateFunc: validation.StringInSlice([]string{apiTransportName, restTransportName}, false),
+				Description:  "Protocol used to talk to the router, either the binary `api` or the RouterOS 7 `rest` API",
+			},
+			"retry_backoff": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("MIKROTIK_RETRY_BACKOFF", defaultRetryBackoff.String()),
+				Description: "Delay before the first retry, doubled for every following retry",
+			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"mikrotik_interface_gre":            resourceInterfaceGre(),
-			"mikrotik_ip_address":               resourceIpAddress(),
-			"mikrotik_ip_firewall_address_list": resourceIpFirewallAddressList(),
-			"mikrotik_ip_firewall_filter":       resourceIpFirewallFilter(),
+			"mikrotik_interface_6to4":               resourceInterface6to4(),
+			"mikrotik_interface_bridge":             resourceInterfaceBridge(),
+			"mikrotik_interface_bridge_port":        resourceInterfaceBridgePort(),
+			"mikrotik_interface_bridge_vlan":        resourceInterfaceBridgeVlan(),
+			"mikrotik_interface_eoip":               resourceInterfaceEoip(),
+			"mikrotik_interface_gre":                resourceInterfaceGre(),
+			"mikrotik_interface_ipip":               resourceInterfaceIpip(),
+			"mikrotik_interface_list":               resourceInterfaceList(),
+			"mikrotik_interface_list_member":        resourceInterfaceListMember(),
+			"mikrotik_interface_vlan":               resourceInterfaceVlan(),
+			"mikrotik_interface_wireguard":          resourceInterfaceWireguard(),
+			"mikrotik_interface_wireguard_peer":     resourceInterfaceWireguardPeer(),
+			"mikrotik_ip_address":                   resourceIpAddress(),
+			"mikrotik_ip_firewall_address_list":     resourceIpFirewallAddressList(),
+			"mikrotik_ip_firewall_address_list_set": resourceIpFirewallAddressListSet(),
+			"mikrotik_ip_firewall_filter":           resourceIpFirewallFilter(),
+			"mikrotik_ip_firewall_filter_rules":     resourceIpFirewallFilterRules(),
+			"mikrotik_ip_firewall_mangle":           resourceIpFirewallMangle(),
+			"mikrotik_ip_firewall_nat":              resourceIpFirewallNat(),
+			"mikrotik_ip_firewall_raw":              resourceIpFirewallRaw(),
+			"mikrotik_ip_route":                     resourceIpRoute(),
+			"mikrotik_ipv6_address":                 resourceIpv6Address(),
+			"mikrotik_ipv6_firewall_address_list":   resourceIpv6FirewallAddressList(),
+			"mikrotik_ipv6_firewall_filter":         resourceIpv6FirewallFilter(),
+			"mikrotik_ipv6_firewall_mangle":         resourceIpv6FirewallMangle(),
+			"mikrotik_ipv6_firewall_nat":            resourceIpv6FirewallNat(),
+			"mikrotik_ipv6_route":                   resourceIpv6Route(),
 		},
-		ConfigureFunc: mikrotikConfigure,
 	}
+	provider.ConfigureFunc = func(d *schema.ResourceData) (interface{}, error) {
+		c, err := mikrotikConfigure(d)
+		if err != nil {
+			return nil, err
+		}
+
+		// Close the shared connection once terraform stops the provider
+		go func() {
+			<-provider.StopContext().Done()
+			c.(mikrotikConfig).Close()
+		}()
+
+		return c, nil
+	}
+
+	return provider
 }
 
 func mikrotikConfigure(d *schema.ResourceData) (interface{}, error) {
@@ -54,11 +158,46 @@ func mikrotikConfigure(d *schema.ResourceData) (interface{}, error) {
 	username := d.Get("username").(string)
 	password := d.Get("password").(string)
 	c := NewClient(address, username, password)
+	c.TLS = d.Get("tls").(bool)
+	c.CA = d.Get("ca_certificate").(string)
+	c.Insecure = d.Get("insecure").(bool)
+	c.ClientCertificate = d.Get("client_certificate").(string)
+	c.ClientKey = d.Get("client_key").(string)
+	c.MaxRetries = d.Get("max_retries").(int)
+
+	var err error
+	c.Transport = d.Get("transport").(string)
+	if c.transport, err = newTransport(c.Transport); err != nil {
+		return nil, err
+	}
+
+	if c.ConnectTimeout, err = parseProviderDuration(d, "connect_timeout"); err != nil {
+		return nil, err
+	}
+	if c.CommandTimeout, err = parseProviderDuration(d, "command_timeout"); err != nil {
+		return nil, err
+	}
+	if c.RetryBackoff, err = parseProviderDuration(d, "retry_backoff"); err != nil {
+		return nil, err
+	}
+
+	if err := c.Valid(); err != nil {
+		return nil, err
+	}
 
 	return c, nil
 
 }
 
+func parseProviderDuration(d *schema.ResourceData, key string) (time.Duration, error) {
+	value := d.Get(key).(string)
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be a duration such as 30s for the mikrotik provider: %v", key, err)
+	}
+	return duration, nil
+}
+
 func (c mikrotikConfig) Valid() error {
 	if c.Host == "" {
 		return fmt.Errorf("hostname must be provieded for the mikrotik provider")
@@ -72,46 +211,169 @@ func (c mikrotikConfig) Valid() error {
 		return fmt.Errorf("password must be provided for the mikrotik provider")
 	}
 
+	if (c.ClientCertificate == "") != (c.ClientKey == "") {
+		return fmt.Errorf("client_certificate and client_key must be provided together for the mikrotik provider")
+	}
+
+	if !c.TLS && (c.CA != "" || c.Insecure || c.ClientCertificate != "") {
+		return fmt.Errorf("ca_certificate, insecure and client_certificate require tls to be enabled for the mikrotik provider")
+	}
+
+	if c.ConnectTimeout < 0 || c.CommandTimeout < 0 || c.RetryBackoff < 0 {
+		return fmt.Errorf("connect_timeout, command_timeout and retry_backoff cannot be negative for the mikrotik provider")
+	}
+
+	if c.MaxRetries < 0 {
+		return fmt.Errorf("max_retries cannot be negative for the mikrotik provider")
+	}
+
 	return nil
 }
 
+const (
+	defaultConnectTimeout  = 10 * time.Second
+	defaultCommandTimeout  = 60 * time.Second
+	defaultMaxRetries      = 3
+	defaultRetryBackoff    = time.Second
+	defaultResourceTimeout = 5 * time.Minute
+)
+
+// resourceTimeouts is shared by every resource so `timeouts {}` blocks
+// bound the retries of their API commands.
+func resourceTimeouts() *schema.ResourceTimeout {
+	return &schema.ResourceTimeout{
+		Create: schema.DefaultTimeout(defaultResourceTimeout),
+		Read:   schema.DefaultTimeout(defaultResourceTimeout),
+		Update: schema.DefaultTimeout(defaultResourceTimeout),
+		Delete: schema.DefaultTimeout(defaultResourceTimeout),
+	}
+}
+
 type mikrotikConfig struct {
-	Host     string
-	Username string
-	Password string
+	Host              string
+	Username          string
+	Password          string
+	TLS               bool
+	CA                string
+	Insecure          bool
+	ClientCertificate string
+	ClientKey         string
+	ConnectTimeout    time.Duration
+	CommandTimeout    time.Duration
+	MaxRetries        int
+	RetryBackoff      time.Duration
+	Transport         string
+
+	transport mikrotikTransport
+	version   *routerVersion
+	planned   *plannedNames
+	deadline  time.Time
 }
 
 func NewClient(host, username, password string) mikrotikConfig {
 	return mikrotikConfig{
-		Host:     host,
-		Username: username,
-		Password: password,
+		Host:           host,
+		Username:       username,
+		Password:       password,
+		ConnectTimeout: defaultConnectTimeout,
+		CommandTimeout: defaultCommandTimeout,
+		MaxRetries:     defaultMaxRetries,
+		RetryBackoff:   defaultRetryBackoff,
+		Transport:      apiTransportName,
+		transport:      &apiTransport{},
+		version:        &routerVersion{},
+		planned:        &plannedNames{},
 	}
 }
 
+// withTimeout returns a copy of the client whose commands, including any
+// retries, must complete within timeout.
+func (client mikrotikConfig) withTimeout(timeout time.Duration) mikrotikConfig {
+	client.deadline = time.Now().Add(timeout)
+	return client
+}
+
+// Run sends a command to the router over the configured transport.
+// Commands failing with a retryable error, see isRetryableError, are
+// retried with exponential backoff up to MaxRetries times, or until the
+// deadline set by withTimeout passes. A reused connection that turns out to
+// be dead is replaced without counting as a retry. The transport checks the
+// connection before sending other commands than prints, which are the only
+// ones run again on the new connection.
+func (client mikrotikConfig) Run(cmd []string) (*routeros.Reply, error) {
+	backoff := client.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		r, stale, err := client.transport.run(client, cmd)
+		if stale && isReadOnlyCommand(cmd) {
+			r, _, err = client.transport.run(client, cmd)
+		}
+
+		if err == nil || !isRetryableError(cmd, err) || attempt >= client.MaxRetries {
+			return r, err
+		}
+
+		if !client.deadline.IsZero() && time.Now().Add(backoff).After(client.deadline) {
+			log.Printf("[WARN] Not retrying mikrotik command, the timeout would be exceeded: %v", err)
+			return r, err
+		}
+
+		log.Printf("[WARN] Retrying mikrotik command in %s after error: %v", backoff, err)
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+// commandDeadline is the earlier of the command timeout and the deadline
+// of the whole operation.
+func (client mikrotikConfig) commandDeadline() time.Time {
+	var deadline time.Time
+	if client.CommandTimeout > 0 {
+		deadline = time.Now().Add(client.CommandTimeout)
+	}
+	if !client.deadline.IsZero() && (deadline.IsZero() || client.deadline.Before(deadline)) {
+		deadline = client.deadline
+	}
+	return deadline
+}
+
+// Close releases any connection held by the transport.
+func (client mikrotikConfig) Close() {
+	client.transport.close()
+}
+
+// Unmarshal decodes the sentences of reply into v, which must be a pointer to
+// a struct or to a slice of structs. A struct receives at most one sentence
+// and is left untouched when the reply is empty. Attributes are matched to
+// the `mikrotik` tag of a field, or to its lowercase name without a tag, and
+// attributes missing from the sentence leave the field as it is, so a pointer
+// field stays nil when the router did not print the attribute.
 func Unmarshal(reply routeros.Reply, v interface{}) error {
 	rv := reflect.ValueOf(v)
-	elem := rv.Elem()
 
-	if rv.Kind() != reflect.Ptr {
-		panic("Unmarshal cannot work without a pointer")
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("Unmarshal requires a non-nil pointer, received %T", v)
 	}
+	elem := rv.Elem()
 
 	switch elem.Kind() {
 	case reflect.Slice:
 		l := len(reply.Re)
-		if l <= 1 {
-			panic(fmt.Sprintf("Cannot Unmarshal %d sentence(s) into a slice", l))
-		}
-
 		t := elem.Type()
 		d := reflect.MakeSlice(t, l, l)
 
 		for i := 0; i < l; i++ {
 			item := d.Index(i)
-			sentence := reply.Re[i]
+			if item.Kind() == reflect.Ptr {
+				item.Set(reflect.New(t.Elem().Elem()))
+				item = item.Elem()
+			}
+			if item.Kind() != reflect.Struct {
+				return fmt.Errorf("Unmarshal cannot decode a sentence into %s", item.Type())
+			}
 
-			parseStruct(&item, *sentence)
+			if err := parseStruct(&item, *reply.Re[i]); err != nil {
+				return err
+			}
 		}
 		elem.Set(d)
 
@@ -121,21 +383,29 @@ func Unmarshal(reply routeros.Reply, v interface{}) error {
 			return nil
 		}
 		if len(reply.Re) > 1 {
-			msg := fmt.Sprintf("Failed to decode reply: %v", reply)
-			return errors.New(msg)
+			return fmt.Errorf("Failed to decode reply of %d sentences into a single %s: %v", len(reply.Re), elem.Type(), redactReply(&reply))
 		}
 
-		parseStruct(&elem, *reply.Re[0])
+		return parseStruct(&elem, *reply.Re[0])
+
+	default:
+		return fmt.Errorf("Unmarshal cannot decode a reply into %s", elem.Type())
 	}
 
 	return nil
 }
 
-func parseStruct(v *reflect.Value, sentence proto.Sentence) {
+func parseStruct(v *reflect.Value, sentence proto.Sentence) error {
 	elem := *v
 	for i := 0; i < elem.NumField(); i++ {
 		field := elem.Field(i)
 		fieldType := elem.Type().Field(i)
+		if isEmbeddedStruct(fieldType) {
+			if err := parseStruct(&field, sentence); err != nil {
+				return err
+			}
+			continue
+		}
 		tags := strings.Split(fieldType.Tag.Get("mikrotik"), ",")
 
 		path := strings.ToLower(fieldType.Name)
@@ -143,57 +413,204 @@ func parseStruct(v *reflect.Value, sentence proto.Sentence) {
 
 		for _, pair := range sentence.List {
 			if strings.Compare(pair.Key, path) == 0 || strings.Compare(pair.Key, fieldName) == 0 {
-				switch fieldType.Type.Kind() {
-				case reflect.String:
-					field.SetString(pair.Value)
-				case reflect.Bool:
-					b, _ := strconv.ParseBool(pair.Value)
-					field.SetBool(b)
-				case reflect.Int:
-					if contains(tags, "ttlToSeconds") {
-						field.SetInt(int64(ttlToSeconds(pair.Value)))
-					} else {
-						intValue, _ := strconv.Atoi(pair.Value)
-						field.SetInt(int64(intValue))
-					}
+				if err := parseValue(field, pair.Value, tags); err != nil {
+					return fmt.Errorf("failed to decode %s=%q into %s.%s: %v", pair.Key, pair.Value, elem.Type().Name(), fieldType.Name, err)
 				}
-
 			}
 		}
 	}
+	return nil
 }
 
-func ttlToSeconds(ttl string) int {
-	parts := strings.Split(ttl, "d")
+var durationType = reflect.TypeOf(time.Duration(0))
 
-	idx := 0
-	days := 0
-	var err error
-	if len(parts) == 2 {
-		idx = 1
-		days, err = strconv.Atoi(parts[0])
+// parseValue sets field from the value printed by the router. Pointers are
+// allocated as needed, lists are comma separated and durations use the
+// RouterOS notation, see parseMikrotikDuration.
+func parseValue(field reflect.Value, value string, tags []string) error {
+	if field.Kind() == reflect.Ptr {
+		ptr := reflect.New(field.Type().Elem())
+		if err := parseValue(ptr.Elem(), value, tags); err != nil {
+			return err
+		}
+		field.Set(ptr)
+		return nil
+	}
 
-		// We should be parsing an ascii number
-		// if this fails we should fail loudly
+	if field.Type() == durationType {
+		d, err := parseMikrotikDuration(value)
 		if err != nil {
-			panic(err)
+			return err
 		}
+		field.SetInt(int64(d))
+		return nil
+	}
 
-		// In the event we just get days parts[1] will be an
-		// empty string. Just coerce that into 0 seconds.
-		if parts[1] == "" {
-			parts[1] = "0s"
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		b, err := parseMikrotikBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if contains(tags, "ttlToSeconds") {
+			seconds, err := ttlToSeconds(value)
+			if err != nil {
+				return err
+			}
+			if field.OverflowInt(int64(seconds)) {
+				return fmt.Errorf("%d overflows %s", seconds, field.Type())
+			}
+			field.SetInt(int64(seconds))
+		} else {
+			intValue, err := strconv.ParseInt(value, 10, field.Type().Bits())
+			if err != nil {
+				return err
+			}
+			field.SetInt(intValue)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		uintValue, err := strconv.ParseUint(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(uintValue)
+	case reflect.Slice:
+		if field.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported type %s", field.Type())
 		}
+		var list []string
+		if value != "" {
+			list = strings.Split(value, ",")
+		}
+		field.Set(reflect.ValueOf(list).Convert(field.Type()))
+	default:
+		return fmt.Errorf("unsupported type %s", field.Type())
 	}
-	d, err := time.ParseDuration(parts[idx])
+	return nil
+}
 
-	// We should never receive a duration greater than
-	// 23h59m59s. So this should always parse.
+// ttlToSeconds converts a RouterOS duration such as `1d15h20m59s` into whole
+// seconds.
+func ttlToSeconds(ttl string) (int, error) {
+	d, err := parseMikrotikDuration(ttl)
 	if err != nil {
-		panic(err)
+		return 0, err
 	}
-	return 86400*days + int(d)/int(math.Pow10(9))
+	return int(d / time.Second), nil
+}
 
+var mikrotikDurationUnits = map[string]time.Duration{
+	"w":  7 * 24 * time.Hour,
+	"d":  24 * time.Hour,
+	"h":  time.Hour,
+	"m":  time.Minute,
+	"s":  time.Second,
+	"ms": time.Millisecond,
+	"us": time.Microsecond,
+}
+
+// parseMikrotikDuration parses the durations printed by RouterOS. These are
+// a sequence of numbers with a w, d, h, m, s, ms or us unit such as `1w2d3h`,
+// optionally ending with a clock notation (`1d02:03:04`). A number without a
+// unit counts as seconds and the empty string as no duration.
+func parseMikrotikDuration(s string) (time.Duration, error) {
+	var total time.Duration
+
+	rest := s
+	for rest != "" {
+		digits := 0
+		for digits < len(rest) && rest[digits] >= '0' && rest[digits] <= '9' {
+			digits++
+		}
+		if digits == 0 {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+
+		if digits < len(rest) && rest[digits] == ':' {
+			d, err := parseMikrotikClock(rest)
+			if err != nil {
+				return 0, fmt.Errorf("invalid duration %q: %v", s, err)
+			}
+			return addDuration(s, total, d, 1)
+		}
+
+		n, err := strconv.ParseInt(rest[:digits], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q: %v", s, err)
+		}
+		rest = rest[digits:]
+
+		letters := 0
+		for letters < len(rest) && (rest[letters] < '0' || rest[letters] > '9') {
+			letters++
+		}
+		unit := rest[:letters]
+		rest = rest[letters:]
+		if unit == "" {
+			unit = "s"
+		}
+
+		multiplier, ok := mikrotikDurationUnits[unit]
+		if !ok {
+			return 0, fmt.Errorf("invalid duration %q: unknown unit %q", s, unit)
+		}
+		if total, err = addDuration(s, total, time.Duration(n), multiplier); err != nil {
+			return 0, err
+		}
+	}
+
+	return total, nil
+}
+
+// formatMikrotikDuration formats d in the notation of parseMikrotikDuration,
+// e.g. `1d2h30m`. Fractions below a microsecond are dropped.
+func formatMikrotikDuration(d time.Duration) string {
+	if d < time.Microsecond {
+		return "0s"
+	}
+
+	var b strings.Builder
+	for _, unit := range []string{"w", "d", "h", "m", "s", "ms", "us"} {
+		multiplier := mikrotikDurationUnits[unit]
+		if n := d / multiplier; n > 0 {
+			fmt.Fprintf(&b, "%d%s", n, unit)
+			d -= n * multiplier
+		}
+	}
+	return b.String()
+}
+
+// addDuration returns total + n*multiplier, failing when it overflows.
+func addDuration(s string, total, n, multiplier time.Duration) (time.Duration, error) {
+	if n > (math.MaxInt64-total)/multiplier {
+		return 0, fmt.Errorf("duration %q is out of range", s)
+	}
+	return total + n*multiplier, nil
+}
+
+// parseMikrotikClock parses the hh:mm:ss notation.
+func parseMikrotikClock(s string) (time.Duration, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) != 3 {
+		return 0, fmt.Errorf("expected hh:mm:ss, received %q", s)
+	}
+
+	var d time.Duration
+	for i, unit := range []time.Duration{time.Hour, time.Minute, time.Second} {
+		n, err := strconv.ParseUint(parts[i], 10, 16)
+		if err != nil {
+			return 0, err
+		}
+		if i > 0 && n > 59 {
+			return 0, fmt.Errorf("%d is out of range in %q", n, s)
+		}
+		d += time.Duration(n) * unit
+	}
+	return d, nil
 }
 
 func contains(s []string, e string) bool {
@@ -215,17 +632,93 @@ func GetConfigFromEnv() (host, username, password string) {
 	return host, username, password
 }
 
-func (client mikrotikConfig) getMikrotikClient() (c *routeros.Client, err error) {
-	address := client.Host
-	username := client.Username
-	password := client.Password
-	c, err = routeros.Dial(address, username, password)
+// dial connects and logs in to the router, returning the underlying
+// connection as well so deadlines can be applied to later commands.
+func (client mikrotikConfig) dial() (*routeros.Client, net.Conn, error) {
+	dialer := &net.Dialer{Timeout: client.ConnectTimeout}
+
+	var conn net.Conn
+	var err error
+	if client.TLS {
+		var tlsConfig *tls.Config
+		tlsConfig, err = client.tlsConfig()
+		if err != nil {
+			return nil, nil, err
+		}
+		conn, err = tls.DialWithDialer(dialer, "tcp", client.Host, tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", client.Host)
+	}
+
+	if err != nil {
+		log.Printf("[ERROR] Failed to connect to routerOS with error: %v", err)
+		return nil, nil, &dialError{err}
+	}
+
+	if client.ConnectTimeout > 0 {
+		conn.SetDeadline(time.Now().Add(client.ConnectTimeout))
+	}
+
+	c, err := routeros.NewClient(conn)
+	if err == nil {
+		err = c.Login(client.Username, client.Password)
+	}
 
 	if err != nil {
 		log.Printf("[ERROR] Failed to login to routerOS with error: %v", err)
+		conn.Close()
+		// a rejected login is permanent, unlike a connection dropped
+		// while logging in
+		if _, ok := err.(*routeros.DeviceError); !ok {
+			err = &dialError{err}
+		}
+		return nil, nil, err
+	}
+
+	conn.SetDeadline(time.Time{})
+
+	return c, conn, nil
+}
+
+func (client mikrotikConfig) tlsConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: client.Insecure,
+	}
+
+	if client.CA != "" {
+		pem, err := ioutil.ReadFile(client.CA)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read ca_certificate `%s`: %v", client.CA, err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no PEM encoded certificates found in ca_certificate `%s`", client.CA)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if client.ClientCertificate != "" {
+		cert, err := tls.LoadX509KeyPair(client.ClientCertificate, client.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client_certificate `%s`: %v", client.ClientCertificate, err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
 	}
 
-	return
+	return tlsConfig, nil
+}
+
+// parseMikrotikBool parses both the true/false printed by the router and
+// the yes/no accepted in commands.
+func parseMikrotikBool(s string) (bool, error) {
+	switch s {
+	case "yes":
+		return true, nil
+	case "no":
+		return false, nil
+	}
+	return strconv.ParseBool(s)
 }
 
 func boolToMikrotikBool(b bool) string {
@@ -236,7 +729,57 @@ func boolToMikrotikBool(b bool) string {
 	}
 }
 
+// marshalAttribute returns the `=name=value` word of a field, or false when
+// the field is unset. Nil pointers are unset while any other pointer is sent
+// even when it points to a zero value, whereas plain strings and numbers are
+// unset when zero. Plain bools are always sent.
+func marshalAttribute(name string, value reflect.Value) (string, bool) {
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return "", false
+		}
+		value = value.Elem()
+	} else if value.IsZero() && value.Kind() != reflect.Bool {
+		return "", false
+	}
+
+	return fmt.Sprintf("=%s=%s", name, formatValue(value)), true
+}
+
+// formatValue formats value the way RouterOS expects it in a command, the
+// inverse of parseValue.
+func formatValue(value reflect.Value) string {
+	if value.Type() == durationType {
+		return formatMikrotikDuration(time.Duration(value.Int()))
+	}
+
+	switch value.Kind() {
+	case reflect.Bool:
+		return boolToMikrotikBool(value.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(value.Uint(), 10)
+	case reflect.Slice:
+		list := make([]string, value.Len())
+		for i := range list {
+			list[i] = value.Index(i).String()
+		}
+		return strings.Join(list, ",")
+	}
+	return value.String()
+}
+
+// Marshal formats the tagged fields of s as the attribute words of an API
+// command, joined by spaces.
 func Marshal(s interface{}) string {
+	return strings.Join(marshalAttributes(s), " ")
+}
+
+// marshalAttributes returns the `=name=value` word of every tagged field of
+// s. Unset fields, see marshalAttribute, are left out, as are the `.id` and
+// any readonly attribute, which the router does not accept in add or set.
+func marshalAttributes(s interface{}) []string {
 	var elem reflect.Value
 	rv := reflect.ValueOf(s)
 
@@ -249,27 +792,15 @@ func Marshal(s interface{}) string {
 
 	var attributes []string
 
-	for i := 0; i < elem.NumField(); i++ {
-		value := elem.Field(i)
-		fieldType := elem.Type().Field(i)
-		// supports multiple struct tags--assumes first is mikrotik field name
-		tag := strings.Split(fieldType.Tag.Get("mikrotik"), ",")[0]
-
-		if tag != "" && (!value.IsZero() || value.Kind() == reflect.Bool) {
-			switch value.Kind() {
-			case reflect.Int:
-				intValue := elem.Field(i).Interface().(int)
-				attributes = append(attributes, fmt.Sprintf("=%s=%d", tag, intValue))
-			case reflect.String:
-				stringValue := elem.Field(i).Interface().(string)
-				attributes = append(attributes, fmt.Sprintf("=%s=%s", tag, stringValue))
-			case reflect.Bool:
-				boolValue := elem.Field(i).Interface().(bool)
-				stringBoolValue := boolToMikrotikBool(boolValue)
-				attributes = append(attributes, fmt.Sprintf("=%s=%s", tag, stringBoolValue))
-			}
+	for _, field := range itemFields(elem) {
+		if field.name == ".id" || contains(field.options, "readonly") {
+			continue
+		}
+
+		if word, ok := marshalAttribute(field.name, field.value); ok {
+			attributes = append(attributes, word)
 		}
 	}
 
-	return strings.Join(attributes, " ")
+	return attributes
 }
@@ -1,13 +1,33 @@
 package mikrotik
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/url"
 	"os"
+	"path/filepath"
+	"reflect"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/go-routeros/routeros"
 	"github.com/go-routeros/routeros/proto"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 )
@@ -22,6 +42,16 @@ func init() {
 	}
 }
 
+// testAccClient returns a client of the router given by the MIKROTIK_*
+// environment variables. Like resource.Test, it skips the test unless TF_ACC
+// is set, so tests driving the client directly do not need a router either.
+func testAccClient(t *testing.T) mikrotikConfig {
+	if os.Getenv(resource.TestEnvVar) == "" {
+		t.Skipf("Acceptance tests skipped unless env '%s' set", resource.TestEnvVar)
+	}
+	return NewClient(GetConfigFromEnv())
+}
+
 func testAccPreCheck(t *testing.T) {
 	if v := os.Getenv("MIKROTIK_HOST"); v == "" {
 		t.Fatal("The MIKROTIK_HOST environment variable must be set")
@@ -46,10 +76,16 @@ func TestAccMikrotikProvider_TestTtlToSeconds(t *testing.T) {
 		{141659, "1d15h20m59s"},
 		{228059, "2d15h20m59s"},
 		{86400, "1d"},
+		{777600, "1w2d"},
+		{93784, "1d02:03:04"},
+		{30, "30"},
 	}
 
 	for _, test := range tests {
-		actual := ttlToSeconds(test.input)
+		actual, err := ttlToSeconds(test.input)
+		if err != nil {
+			t.Errorf("Input %s failed with error: %v", test.input, err)
+		}
 		if test.expected != actual {
 			t.Errorf("Input %s returned %d instead of %d", test.input, actual, test.expected)
 		}
@@ -188,7 +224,7 @@ func TestAccMikrotikProvider_TestUnmarshalOnSlices(t *testing.T) {
 
 func TestAccMikrotikProvider_TestUnmarshal_ttlToSeconds(t *testing.T) {
 	ttlStr := "5m"
-	expectedTtl := ttlToSeconds(ttlStr)
+	expectedTtl, _ := ttlToSeconds(ttlStr)
 	testStruct := struct {
 		Ttl int `mikrotik:"ttl,ttlToSeconds"`
 	}{}
@@ -216,6 +252,202 @@ func TestAccMikrotikProvider_TestUnmarshal_ttlToSeconds(t *testing.T) {
 	}
 }
 
+type testUnmarshalTypes struct {
+	Id        string        `mikrotik:".id"`
+	States    []string      `mikrotik:"connection-state"`
+	Timeout   time.Duration `mikrotik:"timeout"`
+	Bytes     int64         `mikrotik:"bytes"`
+	Mtu       uint          `mikrotik:"mtu"`
+	Comment   *string       `mikrotik:"comment"`
+	Disabled  *bool         `mikrotik:"disabled"`
+	Ttl       int           `mikrotik:"ttl,ttlToSeconds"`
+	Untouched string        `mikrotik:"untouched"`
+}
+
+func testSentence(pairs ...string) *proto.Sentence {
+	sentence := &proto.Sentence{Word: "!re", Map: map[string]string{}}
+	for i := 0; i+1 < len(pairs); i += 2 {
+		sentence.List = append(sentence.List, proto.Pair{Key: pairs[i], Value: pairs[i+1]})
+		sentence.Map[pairs[i]] = pairs[i+1]
+	}
+	return sentence
+}
+
+func TestMikrotikProvider_UnmarshalTypes(t *testing.T) {
+	reply := routeros.Reply{Re: []*proto.Sentence{testSentence(
+		".id", "*1",
+		"connection-state", "established,related",
+		"timeout", "1w2d3h4m5s500ms",
+		"bytes", "9223372036854775807",
+		"mtu", "1500",
+		"disabled", "false",
+		"ttl", "1w",
+	)}}
+
+	testStruct := testUnmarshalTypes{Untouched: "kept"}
+	if err := Unmarshal(reply, &testStruct); err != nil {
+		t.Fatalf("Failed to unmarshal with error: %v", err)
+	}
+
+	timeout := 9*24*time.Hour + 3*time.Hour + 4*time.Minute + 5*time.Second + 500*time.Millisecond
+	disabled := false
+	expected := testUnmarshalTypes{
+		Id:        "*1",
+		States:    []string{"established", "related"},
+		Timeout:   timeout,
+		Bytes:     9223372036854775807,
+		Mtu:       1500,
+		Disabled:  &disabled,
+		Ttl:       7 * 86400,
+		Untouched: "kept",
+	}
+	if !reflect.DeepEqual(testStruct, expected) {
+		t.Errorf("Unmarshal returned %+v, expected %+v", testStruct, expected)
+	}
+	if testStruct.Comment != nil {
+		t.Errorf("A missing attribute should leave the pointer unset, received %q", *testStruct.Comment)
+	}
+}
+
+func TestMikrotikProvider_UnmarshalSingleSentenceSlice(t *testing.T) {
+	for _, count := range []int{0, 1, 3} {
+		var re []*proto.Sentence
+		for i := 0; i < count; i++ {
+			re = append(re, testSentence(".id", fmt.Sprintf("*%d", i)))
+		}
+
+		var items []testUnmarshalTypes
+		if err := Unmarshal(routeros.Reply{Re: re}, &items); err != nil {
+			t.Errorf("Failed to unmarshal %d sentences with error: %v", count, err)
+		}
+		if len(items) != count {
+			t.Errorf("Expected %d items, received %d", count, len(items))
+		}
+
+		var pointers []*testUnmarshalTypes
+		if err := Unmarshal(routeros.Reply{Re: re}, &pointers); err != nil || len(pointers) != count {
+			t.Errorf("Failed to unmarshal %d sentences into pointers: %v %v", count, pointers, err)
+		}
+	}
+}
+
+func TestMikrotikProvider_UnmarshalErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		reply   routeros.Reply
+		target  interface{}
+		message string
+	}{
+		{"not a pointer", routeros.Reply{}, testUnmarshalTypes{}, "requires a non-nil pointer"},
+		{"nil pointer", routeros.Reply{}, (*testUnmarshalTypes)(nil), "requires a non-nil pointer"},
+		{"not a struct", routeros.Reply{}, new(string), "cannot decode a reply into string"},
+		{"several sentences", routeros.Reply{Re: []*proto.Sentence{testSentence(), testSentence()}}, &testUnmarshalTypes{}, "2 sentences"},
+		{"invalid int", routeros.Reply{Re: []*proto.Sentence{testSentence("bytes", "many")}}, &testUnmarshalTypes{}, `bytes="many" into testUnmarshalTypes.Bytes`},
+		{"negative uint", routeros.Reply{Re: []*proto.Sentence{testSentence("mtu", "-1")}}, &testUnmarshalTypes{}, "testUnmarshalTypes.Mtu"},
+		{"invalid bool", routeros.Reply{Re: []*proto.Sentence{testSentence("disabled", "maybe")}}, &testUnmarshalTypes{}, "testUnmarshalTypes.Disabled"},
+		{"invalid duration", routeros.Reply{Re: []*proto.Sentence{testSentence("timeout", "1y")}}, &testUnmarshalTypes{}, `unknown unit "y"`},
+		{"invalid ttl", routeros.Reply{Re: []*proto.Sentence{testSentence("ttl", "forever")}}, &testUnmarshalTypes{}, "testUnmarshalTypes.Ttl"},
+	}
+
+	for _, test := range tests {
+		err := Unmarshal(test.reply, test.target)
+		if err == nil || !strings.Contains(err.Error(), test.message) {
+			t.Errorf("%s: expected an error containing %q, received %v", test.name, test.message, err)
+		}
+	}
+	secret := routeros.Reply{Re: []*proto.Sentence{testSentence("private-key", "s3cr3t"), testSentence("private-key", "s3cr3t")}}
+	if err := Unmarshal(secret, &testUnmarshalTypes{}); err == nil || strings.Contains(err.Error(), "s3cr3t") {
+		t.Errorf("Expected an error without the secret of the reply, received %v", err)
+	}
+}
+
+func TestMikrotikProvider_ParseMikrotikDuration(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected time.Duration
+		valid    bool
+	}{
+		{"", 0, true},
+		{"500ms", 500 * time.Millisecond, true},
+		{"1w2d", 9 * 24 * time.Hour, true},
+		{"5m30", 5*time.Minute + 30*time.Second, true},
+		{"00:05:00", 5 * time.Minute, true},
+		{"1d00:00:01", 24*time.Hour + time.Second, true},
+		{"1d00:61:00", 0, false},
+		{"h", 0, false},
+		{"1y", 0, false},
+		{"99999999999999w", 0, false},
+	}
+
+	for _, test := range tests {
+		actual, err := parseMikrotikDuration(test.input)
+		if test.valid && (err != nil || actual != test.expected) {
+			t.Errorf("Input %q returned %v %v instead of %v", test.input, actual, err, test.expected)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("Input %q should be rejected, returned %v", test.input, actual)
+		}
+	}
+}
+
+func TestMikrotikProvider_MarshalTypes(t *testing.T) {
+	comment := ""
+	disabled := false
+	testStruct := testUnmarshalTypes{
+		Id:       "*1",
+		States:   []string{"established", "related"},
+		Timeout:  26*time.Hour + 500*time.Millisecond,
+		Bytes:    -1,
+		Mtu:      1500,
+		Comment:  &comment,
+		Disabled: &disabled,
+	}
+
+	attributes := Marshal(&testStruct)
+	expected := "=connection-state=established,related =timeout=1d2h500ms =bytes=-1 =mtu=1500 =comment= =disabled=no"
+	if attributes != expected {
+		t.Errorf("Marshal returned %q, expected %q", attributes, expected)
+	}
+}
+
+func FuzzMikrotikProvider_Unmarshal(f *testing.F) {
+	f.Add("timeout", "1w2d3h")
+	f.Add("connection-state", "established,related")
+	f.Add("mtu", "18446744073709551615")
+	f.Add("ttl", "1d02:03:04")
+	f.Add("disabled", "yes")
+
+	f.Fuzz(func(t *testing.T, key, value string) {
+		reply := routeros.Reply{Re: []*proto.Sentence{testSentence(key, value)}}
+
+		testStruct := testUnmarshalTypes{}
+		Unmarshal(reply, &testStruct)
+
+		var items []*testUnmarshalTypes
+		Unmarshal(reply, &items)
+	})
+}
+
+func FuzzMikrotikProvider_ParseMikrotikDuration(f *testing.F) {
+	f.Add("1w2d3h4m5s6ms7us")
+	f.Add("1d23:59:59")
+	f.Add("30")
+
+	f.Fuzz(func(t *testing.T, s string) {
+		d, err := parseMikrotikDuration(s)
+		if err != nil {
+			return
+		}
+		if d < 0 {
+			t.Errorf("Input %q returned the negative duration %v", s, d)
+		}
+		formatted := formatMikrotikDuration(d)
+		if roundTrip, err := parseMikrotikDuration(formatted); err != nil || roundTrip != d.Truncate(time.Microsecond) {
+			t.Errorf("Input %q formatted as %q parses back to %v %v instead of %v", s, formatted, roundTrip, err, d)
+		}
+	})
+}
+
 func TestAccMikrotikProvider_TestMarshal(t *testing.T) {
 	name := "test owner"
 	owner := "admin"
@@ -269,3 +501,404 @@ func TestAccMikrotikProvider_TestMarshalStructWithoutTags(t *testing.T) {
 		t.Errorf("Marshaling with a struct without tags shoudl return empty attributes for command: %v does not equal expected %v", attributes, expectedAttributes)
 	}
 }
+
+func TestMikrotikProvider_TLSWithCACertificate(t *testing.T) {
+	cert, certFile, _ := testTLSCertificate(t, "server")
+	address := testTLSListener(t, cert, nil)
+
+	c := NewClient(address, "admin", "password")
+	c.TLS = true
+	c.CA = certFile
+
+	client, _, err := c.dial()
+	if err != nil {
+		t.Fatalf("Failed to connect over tls with error: %v", err)
+	}
+	client.Close()
+}
+
+func TestMikrotikProvider_TLSUnknownAuthority(t *testing.T) {
+	cert, _, _ := testTLSCertificate(t, "server")
+	address := testTLSListener(t, cert, nil)
+
+	c := NewClient(address, "admin", "password")
+	c.TLS = true
+
+	_, _, err := c.dial()
+	if err == nil {
+		t.Fatal("Connecting to a router with an untrusted certificate should fail")
+	}
+	if !strings.Contains(err.Error(), "unknown authority") {
+		t.Errorf("Expected an unknown authority error, received: %v", err)
+	}
+
+	c.Insecure = true
+	client, _, err := c.dial()
+	if err != nil {
+		t.Fatalf("Failed to connect with insecure set with error: %v", err)
+	}
+	client.Close()
+}
+
+func TestMikrotikProvider_TLSClientCertificate(t *testing.T) {
+	serverCert, caFile, _ := testTLSCertificate(t, "server")
+	clientCert, clientCertFile, clientKeyFile := testTLSCertificate(t, "client")
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientCert.Leaf)
+	address := testTLSListener(t, serverCert, clientCAs)
+
+	c := NewClient(address, "admin", "password")
+	c.TLS = true
+	c.CA = caFile
+
+	if _, _, err := c.dial(); err == nil {
+		t.Fatal("Connecting without a client certificate should fail")
+	}
+
+	c.ClientCertificate = clientCertFile
+	c.ClientKey = clientKeyFile
+	client, _, err := c.dial()
+	if err != nil {
+		t.Fatalf("Failed to connect with a client certificate with error: %v", err)
+	}
+	client.Close()
+}
+
+func TestMikrotikProvider_ConfigValid(t *testing.T) {
+	c := NewClient("router:8729", "admin", "password")
+	if err := c.Valid(); err != nil {
+		t.Errorf("Plain api configuration should be valid: %v", err)
+	}
+
+	c.CA = "/tmp/ca.pem"
+	if err := c.Valid(); err == nil {
+		t.Error("ca_certificate without tls should be rejected")
+	}
+
+	c.TLS = true
+	c.ClientCertificate = "/tmp/client.pem"
+	if err := c.Valid(); err == nil {
+		t.Error("client_certificate without client_key should be rejected")
+	}
+
+	c.ClientKey = "/tmp/client.key"
+	if err := c.Valid(); err != nil {
+		t.Errorf("Complete tls configuration should be valid: %v", err)
+	}
+}
+
+func TestMikrotikProvider_SessionReuse(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	logins := testServeAPI(t, l, nil)
+
+	c := NewClient(l.Addr().String(), "admin", "password")
+	defer c.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.Run([]string{"/system/identity/print"}); err != nil {
+				t.Errorf("Failed to run command with error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := atomic.LoadInt32(logins); n != 1 {
+		t.Errorf("Expected a single login for all commands, received %d", n)
+	}
+
+	// Simulate the router dropping the session
+	c.transport.(*apiTransport).client.Close()
+
+	if _, err := c.Run([]string{"/system/identity/print"}); err != nil {
+		t.Errorf("Failed to reconnect after the session dropped with error: %v", err)
+	}
+	if n := atomic.LoadInt32(logins); n != 2 {
+		t.Errorf("Expected a second login after the session dropped, received %d", n)
+	}
+
+	// a command changing the router is only sent once the session is known
+	// to be alive, as it is not run again on a new one
+	c.transport.(*apiTransport).client.Close()
+	if _, err := c.Run([]string{"/system/identity/set", "=name=router"}); err != nil {
+		t.Errorf("Failed to reconnect before a set after the session dropped with error: %v", err)
+	}
+	if n := atomic.LoadInt32(logins); n != 3 {
+		t.Errorf("Expected a third login after the session dropped, received %d", n)
+	}
+}
+
+func TestMikrotikProvider_RetryableErrors(t *testing.T) {
+	trap := func(word, message string) error {
+		return &routeros.DeviceError{Sentence: &proto.Sentence{Word: word, Map: map[string]string{"message": message}}}
+	}
+	print := []string{"/ip/address/print"}
+	add := []string{"/ip/firewall/address-list/add", "=list=blocklist", "=address=10.0.0.1"}
+	tests := []struct {
+		cmd       []string
+		err       error
+		retryable bool
+	}{
+		{print, io.EOF, true},
+		{add, io.EOF, false},
+		{print, &net.OpError{Op: "dial", Err: errors.New("connection refused")}, true},
+		{add, &net.OpError{Op: "dial", Err: errors.New("connection refused")}, true},
+		{add, &dialError{io.EOF}, true},
+		{add, &url.Error{Op: "Post", Err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}}, true},
+		{add, &net.OpError{Op: "read", Err: errors.New("i/o timeout")}, false},
+		{print, trap("!fatal", "session terminated on request"), true},
+		{add, trap("!fatal", "session terminated on request"), false},
+		{print, trap("!trap", "action timed out - try again"), true},
+		{add, trap("!trap", "action timed out - try again"), false},
+		{print, trap("!trap", "interrupted"), true},
+		{print, trap("!trap", "invalid value for argument timeout"), false},
+		{print, trap("!trap", "invalid value for argument address-list-timeout"), false},
+		{print, trap("!trap", "failure: already have such address"), false},
+		{print, trap("!trap", "no such item"), false},
+		{print, trap("!trap", "invalid user name or password (6)"), false},
+		{print, NewNotFound("ip address `*1` not found"), false},
+		{print, nil, false},
+	}
+
+	for _, test := range tests {
+		if actual := isRetryableError(test.cmd, test.err); actual != test.retryable {
+			t.Errorf("isRetryableError(%v, %v) returned %v instead of %v", test.cmd, test.err, actual, test.retryable)
+		}
+	}
+}
+
+func TestMikrotikProvider_Retry(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var attempts int32
+	testServeAPI(t, l, func(sentence *proto.Sentence) []string {
+		switch sentence.Word {
+		case "/flaky/print":
+			if atomic.AddInt32(&attempts, 1) < 3 {
+				return []string{"!trap", "=message=action timed out - try again"}
+			}
+		case "/broken/print":
+			atomic.AddInt32(&attempts, 1)
+			return []string{"!trap", "=message=no such command"}
+		case "/flaky/add":
+			atomic.AddInt32(&attempts, 1)
+			return []string{"!trap", "=message=action timed out - try again"}
+		}
+		return []string{"!done"}
+	})
+
+	c := NewClient(l.Addr().String(), "admin", "password")
+	c.RetryBackoff = time.Millisecond
+	defer c.Close()
+
+	if _, err := c.Run([]string{"/flaky/print"}); err != nil {
+		t.Errorf("Expected the command to succeed after retries, received: %v", err)
+	}
+	if n := atomic.LoadInt32(&attempts); n != 3 {
+		t.Errorf("Expected 3 attempts, received %d", n)
+	}
+
+	atomic.StoreInt32(&attempts, 0)
+	if _, err := c.Run([]string{"/broken/print"}); err == nil {
+		t.Error("Expected a non retryable trap to be returned")
+	}
+	if n := atomic.LoadInt32(&attempts); n != 1 {
+		t.Errorf("Non retryable errors should not be retried, received %d attempts", n)
+	}
+
+	// the add may have been applied before the router gave up on it
+	atomic.StoreInt32(&attempts, 0)
+	if _, err := c.Run([]string{"/flaky/add"}); err == nil {
+		t.Error("Expected the trap of an add to be returned")
+	}
+	if n := atomic.LoadInt32(&attempts); n != 1 {
+		t.Errorf("Commands changing the router should not be retried, received %d attempts", n)
+	}
+
+	atomic.StoreInt32(&attempts, 0)
+	c.MaxRetries = 1
+	if _, err := c.Run([]string{"/flaky/print"}); err == nil {
+		t.Error("Expected the command to fail once max_retries is exhausted")
+	}
+	if n := atomic.LoadInt32(&attempts); n != 2 {
+		t.Errorf("Expected 2 attempts with max_retries of 1, received %d", n)
+	}
+}
+
+func TestMikrotikProvider_CommandTimeout(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	logins := testServeAPI(t, l, func(sentence *proto.Sentence) []string {
+		if sentence.Word == "/hang/print" {
+			return nil
+		}
+		return []string{"!done"}
+	})
+
+	c := NewClient(l.Addr().String(), "admin", "password")
+	c.CommandTimeout = 50 * time.Millisecond
+	c.MaxRetries = 0
+	defer c.Close()
+
+	start := time.Now()
+	if _, err := c.Run([]string{"/hang/print"}); err == nil {
+		t.Error("Expected a command without a reply to time out")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Command timeout was not honoured, the command took %s", elapsed)
+	}
+
+	// The timed out session must be replaced by a fresh one
+	if _, err := c.Run([]string{"/system/identity/print"}); err != nil {
+		t.Errorf("Failed to run a command after a timeout with error: %v", err)
+	}
+	if n := atomic.LoadInt32(logins); n != 2 {
+		t.Errorf("Expected a new login after the timeout, received %d", n)
+	}
+
+	// The deadline of the operation bounds the retries
+	c.CommandTimeout = 0
+	c.MaxRetries = 10
+	c.RetryBackoff = time.Second
+	start = time.Now()
+	if _, err := c.withTimeout(100 * time.Millisecond).Run([]string{"/hang/print"}); err == nil {
+		t.Error("Expected the operation timeout to stop the command")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Operation timeout was not honoured, the command took %s", elapsed)
+	}
+}
+
+// testTLSCertificate creates a self signed certificate valid for 127.0.0.1
+// and writes it, with its key, to PEM files in a temporary directory.
+func testTLSCertificate(t *testing.T, commonName string) (cert tls.Certificate, certFile, keyFile string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir, err := ioutil.TempDir("", "mikrotik-tls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+	certFile = filepath.Join(dir, commonName+".pem")
+	keyFile = filepath.Join(dir, commonName+".key")
+	if err := ioutil.WriteFile(certFile, certPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(keyFile, keyPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cert, err = tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert.Leaf, err = x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return cert, certFile, keyFile
+}
+
+// testTLSListener starts an API-SSL listener on localhost which accepts any
+// login and returns its address. When clientCAs is set client certificates
+// are required.
+func testTLSListener(t *testing.T, cert tls.Certificate, clientCAs *x509.CertPool) string {
+	config := &tls.Config{Certificates: []tls.Certificate{cert}}
+	if clientCAs != nil {
+		config.ClientCAs = clientCAs
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	l, err := tls.Listen("tcp", "127.0.0.1:0", config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testServeAPI(t, l, nil)
+
+	return l.Addr().String()
+}
+
+// testServeAPI answers every sentence received on l with the words returned
+// by reply, or with !done when reply is nil or returns nothing. A nil slice
+// from reply leaves the command unanswered. It returns a counter of the
+// /login commands seen.
+func testServeAPI(t *testing.T, l net.Listener, reply func(*proto.Sentence) []string) *int32 {
+	t.Cleanup(func() { l.Close() })
+
+	logins := new(int32)
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				r := proto.NewReader(conn)
+				w := proto.NewWriter(conn)
+				for {
+					sentence, err := r.ReadSentence()
+					if err != nil {
+						return
+					}
+					words := []string{"!done"}
+					if sentence.Word == "/login" {
+						atomic.AddInt32(logins, 1)
+					} else if reply != nil {
+						words = reply(sentence)
+						if words == nil {
+							continue
+						}
+					}
+					w.BeginSentence()
+					for _, word := range words {
+						w.WriteWord(word)
+					}
+					if err := w.EndSentence(); err != nil {
+						return
+					}
+				}
+			}()
+		}
+	}()
+
+	return logins
+}
@@ -0,0 +1,25 @@
+package mikrotik
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+var interface6to4Menu = &mikrotikMenu{
+	path: "/interface/6to4",
+	name: "6to4 interface",
+	item: Interface6to4{},
+}
+
+func resourceInterface6to4() *schema.Resource {
+	r := interface6to4Menu.resource()
+	r.Importer.State = interface6to4Menu.importByName
+	return r
+}
+
+// Interface6to4 is an IPv6 over IPv4 tunnel. Without a remote address the
+// tunnel sends to the IPv4 address embedded in the 2002::/16 destination.
+type Interface6to4 struct {
+	Id             string `mikrotik:".id"`
+	Remote_address string `mikrotik:"remote-address"`
+	InterfaceTunnel
+}
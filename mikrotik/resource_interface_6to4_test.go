@@ -0,0 +1,81 @@
+package mikrotik
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccMikrotikResourceInterface6to4_create(t *testing.T) {
+	resourceName := "mikrotik_interface_6to4.autotest"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckMenuItemDestroy(interface6to4Menu, "mikrotik_interface_6to4"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInterface6to4(`remote_address = "198.51.100.2"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMenuItemExists(interface6to4Menu, resourceName),
+					resource.TestCheckResourceAttr(resourceName, "remote_address", "198.51.100.2"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     "autotest-6to4",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccInterface6to4(attributes string) string {
+	return fmt.Sprintf(`
+resource "mikrotik_interface_6to4" "autotest" {
+	name = "autotest-6to4"
+	local_address = "198.51.100.1"
+	comment = "autotest"
+	%s
+}
+`, attributes)
+}
+
+func TestMikrotikResourceInterface6to4_lifecycle(t *testing.T) {
+	f := newFakeRouter()
+	provider := testFakeProvider(newFakeApiServer(t, f))
+	resourceName := "mikrotik_interface_6to4.autotest"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testFakeRouterEmpty(f, "/interface/6to4"),
+		Steps: []resource.TestStep{
+			{
+				// without a remote address the tunnel relays to 2002::/16
+				Config: provider + testAccInterface6to4(""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testFakeRouterLastCommand(f, "/interface/6to4/add", "=clamp-tcp-mss=yes", "=comment=autotest", "=disabled=no", "=dont-fragment=no", "=dscp=inherit", "=keepalive=10s,10", "=local-address=198.51.100.1", "=mtu=auto", "=name=autotest-6to4"),
+					resource.TestCheckResourceAttr(resourceName, "remote_address", ""),
+				),
+			},
+			{
+				Config: provider + testAccInterface6to4(`
+	remote_address = "198.51.100.2"
+	mtu = "1280"
+`),
+				Check: testFakeRouterLastCommand(f, "/interface/6to4/set", "=remote-address=198.51.100.2", "=mtu=1280"),
+			},
+			{
+				Config: provider + testAccInterface6to4(`
+	remote_address = "198.51.100.2"
+	mtu = "1280"
+`),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     "autotest-6to4",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
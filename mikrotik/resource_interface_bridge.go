@@ -0,0 +1,56 @@
+package mikrotik
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+var interfaceBridgeMenu = &mikrotikMenu{
+	path: "/interface/bridge",
+	name: "bridge interface",
+	item: InterfaceBridge{},
+}
+
+// bridgeFrameTypes are the frame types accepted by bridges and their ports.
+var bridgeFrameTypes = []string{"admit-all", "admit-only-untagged-and-priority-tagged", "admit-only-vlan-tagged"}
+
+// resourceInterfaceBridge records the planned name of the bridge, so ports
+// and VLANs referencing a bridge created in the same apply pass their plan
+// time check.
+func resourceInterfaceBridge() *schema.Resource {
+	r := interfaceBridgeMenu.resource()
+	r.Importer.State = interfaceBridgeMenu.importByName
+	r.CustomizeDiff = interfaceBridgeMenu.planName
+	r.Schema["arp"].ValidateFunc = validation.StringInSlice(interfaceArpModes, false)
+	r.Schema["protocol_mode"].ValidateFunc = validation.StringInSlice([]string{"none", "stp", "rstp", "mstp"}, false)
+	r.Schema["frame_types"].ValidateFunc = validation.StringInSlice(bridgeFrameTypes, false)
+	r.Schema["ether_type"].ValidateFunc = validation.StringInSlice([]string{"0x8100", "0x88a8", "0x9100"}, false)
+	r.Schema["pvid"].ValidateFunc = validation.IntBetween(1, 4094)
+	return r
+}
+
+type InterfaceBridge struct {
+	Id                string `mikrotik:".id"`
+	Name              string `mikrotik:"name,required"`
+	Mtu               string `mikrotik:"mtu,default=auto"`
+	Arp               string `mikrotik:"arp,default=enabled"`
+	Admin_mac         string `mikrotik:"admin-mac,computed"`
+	Auto_mac          bool   `mikrotik:"auto-mac,default=true"`
+	Protocol_mode     string `mikrotik:"protocol-mode,default=rstp"`
+	Priority          string `mikrotik:"priority,default=0x8000"`
+	Forward_delay     string `mikrotik:"forward-delay,default=15s"`
+	Max_message_age   string `mikrotik:"max-message-age,default=20s"`
+	Region_name       string `mikrotik:"region-name"`
+	Region_revision   int    `mikrotik:"region-revision,computed"`
+	Igmp_snooping     bool   `mikrotik:"igmp-snooping,default=false"`
+	Vlan_filtering    bool   `mikrotik:"vlan-filtering,default=false"`
+	Ether_type        string `mikrotik:"ether-type,default=0x8100"`
+	Pvid              int    `mikrotik:"pvid,default=1"`
+	Frame_types       string `mikrotik:"frame-types,default=admit-all"`
+	Ingress_filtering *bool  `mikrotik:"ingress-filtering,computed"`
+	Comment           string `mikrotik:"comment"`
+	Disabled          bool   `mikrotik:"disabled,default=false"`
+	Mac_address       string `mikrotik:"mac-address,readonly"`
+	Actual_mtu        int    `mikrotik:"actual-mtu,readonly"`
+	Running           bool   `mikrotik:"running,readonly"`
+}
@@ -0,0 +1,41 @@
+package mikrotik
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+var interfaceBridgePortMenu = &mikrotikMenu{
+	path: "/interface/bridge/port",
+	name: "bridge port",
+	item: InterfaceBridgePort{},
+}
+
+func resourceInterfaceBridgePort() *schema.Resource {
+	r := interfaceBridgePortMenu.resource()
+	r.CustomizeDiff = interfaceBridgeMenu.requireExisting("bridge")
+	r.Schema["frame_types"].ValidateFunc = validation.StringInSlice(bridgeFrameTypes, false)
+	r.Schema["edge"].ValidateFunc = validation.StringInSlice([]string{"auto", "no", "no-discover", "yes", "yes-discover"}, false)
+	r.Schema["point_to_point"].ValidateFunc = validation.StringInSlice([]string{"auto", "no", "yes"}, false)
+	r.Schema["pvid"].ValidateFunc = validation.IntBetween(1, 4094)
+	return r
+}
+
+type InterfaceBridgePort struct {
+	Id                string `mikrotik:".id"`
+	Bridge            string `mikrotik:"bridge,required"`
+	Interface         string `mikrotik:"interface,required"`
+	Pvid              int    `mikrotik:"pvid,default=1"`
+	Frame_types       string `mikrotik:"frame-types,default=admit-all"`
+	Ingress_filtering *bool  `mikrotik:"ingress-filtering,computed"`
+	Horizon           string `mikrotik:"horizon,default=none"`
+	Edge              string `mikrotik:"edge,default=auto"`
+	Point_to_point    string `mikrotik:"point-to-point,default=auto"`
+	Path_cost         int    `mikrotik:"path-cost,computed"`
+	Priority          string `mikrotik:"priority,default=0x80"`
+	Hw                *bool  `mikrotik:"hw,computed"`
+	Comment           string `mikrotik:"comment"`
+	Disabled          bool   `mikrotik:"disabled,default=false"`
+	Dynamic           bool   `mikrotik:"dynamic,readonly"`
+	Inactive          bool   `mikrotik:"inactive,readonly"`
+}
@@ -0,0 +1,148 @@
+package mikrotik
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccMikrotikResourceInterfaceBridgePort_create(t *testing.T) {
+	resourceName := "mikrotik_interface_bridge_port.autotest"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckMenuItemDestroy(interfaceBridgePortMenu, "mikrotik_interface_bridge_port"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInterfaceBridgePort(`pvid = 10`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMenuItemExists(interfaceBridgePortMenu, resourceName),
+					resource.TestCheckResourceAttr(resourceName, "bridge", "autotest-br"),
+					resource.TestCheckResourceAttr(resourceName, "pvid", "10"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccInterfaceBridgePort(attributes string) string {
+	return fmt.Sprintf(`
+resource "mikrotik_interface_bridge" "autotest" {
+	name = "autotest-br"
+	vlan_filtering = true
+}
+
+resource "mikrotik_interface_bridge_port" "autotest" {
+	bridge = mikrotik_interface_bridge.autotest.name
+	interface = "ether2"
+	comment = "autotest"
+	%s
+}
+`, attributes)
+}
+
+func TestMikrotikResourceInterfaceBridgePort_lifecycle(t *testing.T) {
+	f := newFakeRouter()
+	provider := testFakeProvider(newFakeApiServer(t, f))
+	resourceName := "mikrotik_interface_bridge_port.autotest"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testFakeRouterEmpty(f, "/interface/bridge/port"),
+		Steps: []resource.TestStep{
+			{
+				// the bridge is created in the same apply
+				Config: provider + testAccInterfaceBridgePort(`
+	pvid = 10
+	frame_types = "admit-only-untagged-and-priority-tagged"
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testFakeRouterItem(f, "/interface/bridge/port", resourceName, map[string]string{
+						"bridge":      "autotest-br",
+						"interface":   "ether2",
+						"pvid":        "10",
+						"frame-types": "admit-only-untagged-and-priority-tagged",
+						"edge":        "auto",
+						"hw":          "",
+					}),
+				),
+			},
+			{
+				Config: provider + testAccInterfaceBridgePort(`
+	pvid = 20
+	frame_types = "admit-only-untagged-and-priority-tagged"
+	ingress_filtering = true
+	edge = "yes"
+`),
+				Check: testFakeRouterLastCommand(f, "/interface/bridge/port/set", "=pvid=20", "=ingress-filtering=yes", "=edge=yes"),
+			},
+			{
+				Config: provider + testAccInterfaceBridgePort(`
+	pvid = 20
+	frame_types = "admit-only-untagged-and-priority-tagged"
+	ingress_filtering = true
+	edge = "yes"
+`),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccInterfaceBridgePortOf(bridge string) string {
+	return fmt.Sprintf(`
+resource "mikrotik_interface_bridge_port" "autotest" {
+	bridge = "%s"
+	interface = "ether2"
+}
+`, bridge)
+}
+
+func TestMikrotikResourceInterfaceBridgePort_existingBridge(t *testing.T) {
+	f := newFakeRouter()
+	f.tables["/interface/bridge"] = []map[string]string{
+		{".id": "*B1", "name": "bridge1"},
+	}
+	provider := testFakeProvider(newFakeApiServer(t, f))
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testFakeRouterEmpty(f, "/interface/bridge/port"),
+		Steps: []resource.TestStep{
+			{
+				Config: provider + testAccInterfaceBridgePortOf("bridge1"),
+				Check: testFakeRouterItem(f, "/interface/bridge/port", "mikrotik_interface_bridge_port.autotest", map[string]string{
+					"bridge": "bridge1",
+				}),
+			},
+		},
+	})
+}
+
+func TestMikrotikResourceInterfaceBridgePort_unknownBridge(t *testing.T) {
+	f := newFakeRouter()
+	f.tables["/interface/bridge"] = []map[string]string{
+		{".id": "*B1", "name": "bridge1"},
+	}
+	provider := testFakeProvider(newFakeApiServer(t, f))
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testFakeRouterEmpty(f, "/interface/bridge/port"),
+		Steps: []resource.TestStep{
+			{
+				Config:      provider + testAccInterfaceBridgePortOf("brdige1"),
+				ExpectError: regexp.MustCompile("bridge references the bridge interface `brdige1`, which does not exist"),
+			},
+		},
+	})
+}
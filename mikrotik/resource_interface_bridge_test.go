@@ -0,0 +1,108 @@
+package mikrotik
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccMikrotikResourceInterfaceBridge_create(t *testing.T) {
+	resourceName := "mikrotik_interface_bridge.autotest"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckMenuItemDestroy(interfaceBridgeMenu, "mikrotik_interface_bridge"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInterfaceBridge(`protocol_mode = "rstp"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMenuItemExists(interfaceBridgeMenu, resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", "autotest-br"),
+					resource.TestCheckResourceAttr(resourceName, "vlan_filtering", "false"),
+				),
+			},
+			{
+				Config: testAccInterfaceBridge(`
+	protocol_mode = "mstp"
+	region_name = "autotest"
+	vlan_filtering = true
+	frame_types = "admit-only-vlan-tagged"
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMenuItemExists(interfaceBridgeMenu, resourceName),
+					resource.TestCheckResourceAttr(resourceName, "protocol_mode", "mstp"),
+					resource.TestCheckResourceAttr(resourceName, "vlan_filtering", "true"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     "autotest-br",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccInterfaceBridge(attributes string) string {
+	return fmt.Sprintf(`
+resource "mikrotik_interface_bridge" "autotest" {
+	name = "autotest-br"
+	comment = "autotest"
+	%s
+}
+`, attributes)
+}
+
+func TestMikrotikResourceInterfaceBridge_lifecycle(t *testing.T) {
+	f := newFakeRouter()
+	provider := testFakeProvider(newFakeApiServer(t, f))
+	resourceName := "mikrotik_interface_bridge.autotest"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testFakeRouterEmpty(f, "/interface/bridge"),
+		Steps: []resource.TestStep{
+			{
+				Config: provider + testAccInterfaceBridge(""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testFakeRouterItem(f, "/interface/bridge", resourceName, map[string]string{
+						"name":              "autotest-br",
+						"protocol-mode":     "rstp",
+						"vlan-filtering":    "no",
+						"pvid":              "1",
+						"frame-types":       "admit-all",
+						"ingress-filtering": "",
+					}),
+				),
+			},
+			{
+				Config: provider + testAccInterfaceBridge(`
+	protocol_mode = "mstp"
+	region_name = "autotest"
+	vlan_filtering = true
+	ingress_filtering = true
+	frame_types = "admit-only-vlan-tagged"
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testFakeRouterLastCommand(f, "/interface/bridge/set", "=protocol-mode=mstp", "=region-name=autotest", "=vlan-filtering=yes", "=frame-types=admit-only-vlan-tagged", "=ingress-filtering=yes"),
+					resource.TestCheckResourceAttr(resourceName, "ingress_filtering", "true"),
+				),
+			},
+			{
+				Config: provider + testAccInterfaceBridge(`
+	protocol_mode = "mstp"
+	region_name = "autotest"
+	vlan_filtering = true
+	ingress_filtering = true
+	frame_types = "admit-only-vlan-tagged"
+`),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     "autotest-br",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
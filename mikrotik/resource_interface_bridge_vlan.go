@@ -0,0 +1,34 @@
+package mikrotik
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+var interfaceBridgeVlanMenu = &mikrotikMenu{
+	path: "/interface/bridge/vlan",
+	name: "bridge vlan",
+	item: InterfaceBridgeVlan{},
+}
+
+func resourceInterfaceBridgeVlan() *schema.Resource {
+	r := interfaceBridgeVlanMenu.resource()
+	r.CustomizeDiff = interfaceBridgeMenu.requireExisting("bridge")
+	r.Schema["vlan_ids"].Elem.(*schema.Schema).ValidateFunc = validateVlanIds
+	return r
+}
+
+// InterfaceBridgeVlan is an entry of the VLAN table of a bridge. The router
+// adds the bridge and the ports using a VLAN as their pvid to the untagged
+// ports on its own, which it reports in current_untagged.
+type InterfaceBridgeVlan struct {
+	Id               string   `mikrotik:".id"`
+	Bridge           string   `mikrotik:"bridge,required"`
+	Vlan_ids         []string `mikrotik:"vlan-ids,required,set"`
+	Tagged           []string `mikrotik:"tagged,set"`
+	Untagged         []string `mikrotik:"untagged,set"`
+	Comment          string   `mikrotik:"comment"`
+	Disabled         bool     `mikrotik:"disabled,default=false"`
+	Current_tagged   []string `mikrotik:"current-tagged,readonly"`
+	Current_untagged []string `mikrotik:"current-untagged,readonly"`
+	Dynamic          bool     `mikrotik:"dynamic,readonly"`
+}
@@ -0,0 +1,187 @@
+package mikrotik
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccMikrotikResourceInterfaceBridgeVlan_create(t *testing.T) {
+	resourceName := "mikrotik_interface_bridge_vlan.autotest"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckMenuItemDestroy(interfaceBridgeVlanMenu, "mikrotik_interface_bridge_vlan"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInterfaceBridgeVlan(`
+	vlan_ids = ["10"]
+	tagged = ["autotest-br", "ether2"]
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMenuItemExists(interfaceBridgeVlanMenu, resourceName),
+					resource.TestCheckResourceAttr(resourceName, "vlan_ids.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tagged.#", "2"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccInterfaceBridgeVlan(attributes string) string {
+	return fmt.Sprintf(`
+resource "mikrotik_interface_bridge" "autotest" {
+	name = "autotest-br"
+	vlan_filtering = true
+}
+
+resource "mikrotik_interface_bridge_vlan" "autotest" {
+	bridge = mikrotik_interface_bridge.autotest.name
+	comment = "autotest"
+	%s
+}
+`, attributes)
+}
+
+// testFakeRouterPorts returns a check that the attribute of the bridge VLAN
+// holds the given ports on the fake router, in any order.
+func testFakeRouterPorts(f *fakeRouter, resourceName, attribute string, ports ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resourceName)
+		}
+
+		item, err := f.find("/interface/bridge/vlan", rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		actual := strings.Split(item[attribute], ",")
+		sort.Strings(actual)
+		sort.Strings(ports)
+		if strings.Join(actual, ",") != strings.Join(ports, ",") {
+			return fmt.Errorf("Expected the %s ports %v, the router has %v", attribute, ports, actual)
+		}
+		return nil
+	}
+}
+
+func TestMikrotikResourceInterfaceBridgeVlan_lifecycle(t *testing.T) {
+	f := newFakeRouter()
+	provider := testFakeProvider(newFakeApiServer(t, f))
+	resourceName := "mikrotik_interface_bridge_vlan.autotest"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testFakeRouterEmpty(f, "/interface/bridge/vlan"),
+		Steps: []resource.TestStep{
+			{
+				Config: provider + testAccInterfaceBridgeVlan(`
+	vlan_ids = ["10", "20-29"]
+	tagged = ["autotest-br", "ether2", "ether3"]
+	untagged = ["ether4"]
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testFakeRouterPorts(f, resourceName, "vlan-ids", "10", "20-29"),
+					testFakeRouterPorts(f, resourceName, "tagged", "autotest-br", "ether2", "ether3"),
+					testFakeRouterPorts(f, resourceName, "untagged", "ether4"),
+					resource.TestCheckResourceAttr(resourceName, "tagged.#", "3"),
+				),
+			},
+			{
+				// the router prints the ports in its own order
+				PreConfig: func() {
+					for _, item := range f.tables["/interface/bridge/vlan"] {
+						item["tagged"] = "ether3,ether2,autotest-br"
+						item["current-tagged"] = "autotest-br,ether2"
+						item["current-untagged"] = "ether4"
+					}
+				},
+				Config: provider + testAccInterfaceBridgeVlan(`
+	vlan_ids = ["10", "20-29"]
+	tagged = ["autotest-br", "ether2", "ether3"]
+	untagged = ["ether4"]
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "current_tagged.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "current_untagged.0", "ether4"),
+					func(s *terraform.State) error {
+						if cmd := f.lastCommand("/interface/bridge/vlan/set"); cmd != nil {
+							return fmt.Errorf("The order of the ports should not be a difference, the router received %v", cmd)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: provider + testAccInterfaceBridgeVlan(`
+	vlan_ids = ["10", "20-29"]
+	tagged = ["autotest-br", "ether3"]
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testFakeRouterPorts(f, resourceName, "tagged", "autotest-br", "ether3"),
+					testFakeRouterPorts(f, resourceName, "untagged", ""),
+				),
+			},
+			{
+				Config: provider + testAccInterfaceBridgeVlan(`
+	vlan_ids = ["10", "20-29"]
+	tagged = ["autotest-br", "ether3"]
+`),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestMikrotikResourceInterfaceBridgeVlan_unknownBridge(t *testing.T) {
+	f := newFakeRouter()
+	provider := testFakeProvider(newFakeApiServer(t, f))
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testFakeRouterEmpty(f, "/interface/bridge/vlan"),
+		Steps: []resource.TestStep{
+			{
+				Config: provider + `
+resource "mikrotik_interface_bridge_vlan" "autotest" {
+	bridge = "bridge1"
+	vlan_ids = ["10"]
+}
+`,
+				ExpectError: regexp.MustCompile("bridge references the bridge interface `bridge1`, which does not exist"),
+			},
+		},
+	})
+}
+
+func TestMikrotikResourceInterfaceBridgeVlan_invalidVlanIds(t *testing.T) {
+	f := newFakeRouter()
+	provider := testFakeProvider(newFakeApiServer(t, f))
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testFakeRouterEmpty(f, "/interface/bridge/vlan"),
+		Steps: []resource.TestStep{
+			{
+				Config: provider + testAccInterfaceBridgeVlan(`
+	vlan_ids = ["4095"]
+`),
+				ExpectError: regexp.MustCompile(`expected vlan_ids.\d+ to be a VLAN id or range of ids from 1 to 4094`),
+			},
+		},
+	})
+}
@@ -0,0 +1,30 @@
+package mikrotik
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+var interfaceEoipMenu = &mikrotikMenu{
+	path: "/interface/eoip",
+	name: "eoip interface",
+	item: InterfaceEoip{},
+}
+
+func resourceInterfaceEoip() *schema.Resource {
+	r := interfaceEoipMenu.resource()
+	r.Importer.State = interfaceEoipMenu.importByName
+	r.Schema["tunnel_id"].ValidateFunc = validation.IntBetween(0, 65535)
+	r.Schema["arp"].ValidateFunc = validation.StringInSlice(interfaceArpModes, false)
+	return r
+}
+
+// InterfaceEoip is an Ethernet over IP tunnel. Both ends must use the same
+// tunnel id.
+type InterfaceEoip struct {
+	Id          string `mikrotik:".id"`
+	Arp         string `mikrotik:"arp,default=enabled"`
+	Mac_address string `mikrotik:"mac-address,computed"`
+	Tunnel_id   int    `mikrotik:"tunnel-id,required"`
+	InterfaceFastPathTunnel
+}
@@ -0,0 +1,121 @@
+package mikrotik
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccMikrotikResourceInterfaceEoip_create(t *testing.T) {
+	resourceName := "mikrotik_interface_eoip.autotest"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckMenuItemDestroy(interfaceEoipMenu, "mikrotik_interface_eoip"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInterfaceEoip(42, ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMenuItemExists(interfaceEoipMenu, resourceName),
+					resource.TestCheckResourceAttr(resourceName, "tunnel_id", "42"),
+					resource.TestCheckResourceAttrSet(resourceName, "mac_address"),
+				),
+			},
+			{
+				Config: testAccInterfaceEoip(43, `clamp_tcp_mss = false`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMenuItemExists(interfaceEoipMenu, resourceName),
+					resource.TestCheckResourceAttr(resourceName, "tunnel_id", "43"),
+					resource.TestCheckResourceAttr(resourceName, "clamp_tcp_mss", "false"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     "autotest-eoip",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccInterfaceEoip(tunnelId int, attributes string) string {
+	return fmt.Sprintf(`
+resource "mikrotik_interface_eoip" "autotest" {
+	name = "autotest-eoip"
+	remote_address = "198.51.100.2"
+	tunnel_id = %d
+	comment = "autotest"
+	%s
+}
+`, tunnelId, attributes)
+}
+
+func TestMikrotikResourceInterfaceEoip_lifecycle(t *testing.T) {
+	f := newFakeRouter()
+	provider := testFakeProvider(newFakeApiServer(t, f))
+	resourceName := "mikrotik_interface_eoip.autotest"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testFakeRouterEmpty(f, "/interface/eoip"),
+		Steps: []resource.TestStep{
+			{
+				Config: provider + testAccInterfaceEoip(42, `
+	local_address = "198.51.100.1"
+	ipsec_secret = "autotest"
+`),
+				Check: testFakeRouterItem(f, "/interface/eoip", resourceName, map[string]string{
+					"name":           "autotest-eoip",
+					"remote-address": "198.51.100.2",
+					"local-address":  "198.51.100.1",
+					"tunnel-id":      "42",
+					"ipsec-secret":   "autotest",
+					"keepalive":      "10s,10",
+					"clamp-tcp-mss":  "yes",
+					"arp":            "enabled",
+					"mac-address":    "",
+				}),
+			},
+			{
+				Config: provider + testAccInterfaceEoip(43, `
+	local_address = "198.51.100.1"
+	ipsec_secret = "autotest"
+	mac_address = "02:00:00:00:00:42"
+	dscp = "10"
+`),
+				Check: testFakeRouterLastCommand(f, "/interface/eoip/set", "=mac-address=02:00:00:00:00:42", "=tunnel-id=43", "=dscp=10"),
+			},
+			{
+				Config: provider + testAccInterfaceEoip(43, `
+	local_address = "198.51.100.1"
+	ipsec_secret = "autotest"
+	mac_address = "02:00:00:00:00:42"
+	dscp = "10"
+`),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     "autotest-eoip",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestMikrotikResourceInterfaceEoip_invalidTunnelId(t *testing.T) {
+	f := newFakeRouter()
+	provider := testFakeProvider(newFakeApiServer(t, f))
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testFakeRouterEmpty(f, "/interface/eoip"),
+		Steps: []resource.TestStep{
+			{
+				Config:      provider + testAccInterfaceEoip(65536, ""),
+				ExpectError: regexp.MustCompile(`expected tunnel_id to be in the range \(0 - 65535\)`),
+			},
+		},
+	})
+}
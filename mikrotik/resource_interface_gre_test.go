@@ -180,8 +180,56 @@ func testAccCheckMikrotikInterfaceGreDestroy(s *terraform.State) error {
 	return nil
 }
 
+func TestMikrotikResourceInterfaceGre_lifecycle(t *testing.T) {
+	f := newFakeRouter()
+	provider := testFakeProvider(newFakeApiServer(t, f))
+	resourceName := "mikrotik_interface_gre.autotest"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testFakeRouterEmpty(f, "/interface/gre"),
+		Steps: []resource.TestStep{
+			{
+				Config: provider + testAccInterfaceGre(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testFakeRouterItem(f, "/interface/gre", resourceName, map[string]string{
+						"name":           origGreName,
+						"comment":        origGreComment,
+						"remote-address": origGreRemoteAddr,
+					}),
+					resource.TestCheckResourceAttr(resourceName, "name", origGreName),
+				),
+			},
+			{
+				Config: provider + testAccInterfaceGreUpdateName(),
+				Check: testFakeRouterItem(f, "/interface/gre", resourceName, map[string]string{
+					"name": updatedGreName,
+				}),
+			},
+			{
+				Config: provider + testAccInterfaceGreUpdatedComment(),
+				Check: testFakeRouterItem(f, "/interface/gre", resourceName, map[string]string{
+					"comment": updatedGreComment,
+				}),
+			},
+			{
+				Config: provider + testAccInterfaceGreUpdatedRemoteAddress(),
+				Check: testFakeRouterItem(f, "/interface/gre", resourceName, map[string]string{
+					"remote-address": updatedGreRemoteAddr,
+				}),
+			},
+			{
+				Config:            provider + testAccInterfaceGreUpdatedRemoteAddress(),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
 func TestAccMikrotikResourceInterfaceGre_add_delete(t *testing.T) {
-	c := NewClient(GetConfigFromEnv())
+	c := testAccClient(t)
 
 	var allow_fast_path (bool) = true
 	var clamp_tcp_mss (bool) = true
@@ -198,19 +246,23 @@ func TestAccMikrotikResourceInterfaceGre_add_delete(t *testing.T) {
 	var remote_address (string) = "2.2.2.2"
 
 	expectedGreIf := &InterfaceGre{
-		Allow_fast_path: allow_fast_path,
-		Clamp_tcp_mss:   clamp_tcp_mss,
-		Comment:         comment,
-		Copy_from:       copy_from,
-		Disabled:        disabled,
-		Dont_fragment:   dont_fragment,
-		Dscp:            dscp,
-		Ipsec_secret:    ipsec_secret,
-		Keepalive:       keepalive,
-		Local_address:   local_address,
-		Mtu:             mtu,
-		Name:            name,
-		Remote_address:  remote_address,
+		InterfaceFastPathTunnel: InterfaceFastPathTunnel{
+			Allow_fast_path: allow_fast_path,
+			Remote_address:  remote_address,
+			InterfaceTunnel: InterfaceTunnel{
+				Clamp_tcp_mss: clamp_tcp_mss,
+				Comment:       comment,
+				Copy_from:     copy_from,
+				Disabled:      disabled,
+				Dont_fragment: dont_fragment,
+				Dscp:          dscp,
+				Ipsec_secret:  ipsec_secret,
+				Keepalive:     keepalive,
+				Local_address: local_address,
+				Mtu:           mtu,
+				Name:          name,
+			},
+		},
 	}
 
 	greif, err := c.AddInterfaceGre(allow_fast_path, clamp_tcp_mss, comment, copy_from, disabled, dont_fragment, dscp, ipsec_secret, keepalive, local_address, mtu, name, remote_address)
@@ -280,7 +332,7 @@ func TestAccMikrotikResourceInterfaceGre_add_delete(t *testing.T) {
 }
 
 func TestAccMikrotikResourceInterfaceGre_add_update_delete(t *testing.T) {
-	c := NewClient(GetConfigFromEnv())
+	c := testAccClient(t)
 
 	var initial_allow_fast_path (bool) = true
 	var updated_allow_fast_path (bool) = false
@@ -303,19 +355,23 @@ func TestAccMikrotikResourceInterfaceGre_add_update_delete(t *testing.T) {
 	var updated_remote_address (string) = "23.23.23.23"
 
 	expectedGreIf := &InterfaceGre{
-		Allow_fast_path: updated_allow_fast_path,
-		Clamp_tcp_mss:   updated_clamp_tcp_mss,
-		Comment:         updated_comment,
-		Copy_from:       copy_from,
-		Disabled:        updated_disabled,
-		Dont_fragment:   dont_fragment,
-		Dscp:            dscp,
-		Ipsec_secret:    ipsec_secret,
-		Keepalive:       keepalive,
-		Local_address:   local_address,
-		Mtu:             mtu,
-		Name:            updated_name,
-		Remote_address:  updated_remote_address,
+		InterfaceFastPathTunnel: InterfaceFastPathTunnel{
+			Allow_fast_path: updated_allow_fast_path,
+			Remote_address:  updated_remote_address,
+			InterfaceTunnel: InterfaceTunnel{
+				Clamp_tcp_mss: updated_clamp_tcp_mss,
+				Comment:       updated_comment,
+				Copy_from:     copy_from,
+				Disabled:      updated_disabled,
+				Dont_fragment: dont_fragment,
+				Dscp:          dscp,
+				Ipsec_secret:  ipsec_secret,
+				Keepalive:     keepalive,
+				Local_address: local_address,
+				Mtu:           mtu,
+				Name:          updated_name,
+			},
+		},
 	}
 
 	initial_greif, err := c.AddInterfaceGre(initial_allow_fast_path, initial_clamp_tcp_mss, initial_comment, copy_from, initial_disabled, dont_fragment, dscp, ipsec_secret, keepalive, local_address, mtu, initial_name, initial_remote_address)
@@ -391,12 +447,12 @@ func TestAccMikrotikResourceInterfaceGre_add_update_delete(t *testing.T) {
 }
 
 func TestAccMikrotikResourceInterfaceGre_find_nonexisting(t *testing.T) {
-	c := NewClient(GetConfigFromEnv())
+	c := testAccClient(t)
 
 	greifId := "Invalid Id"
 	_, err := c.FindInterfaceGre(greifId)
 
-	expectedErrStr := fmt.Sprintf("gre interface `%s`not found", greifId)
+	expectedErrStr := fmt.Sprintf("gre interface `%s` not found", greifId)
 	if err == nil || err.Error() != expectedErrStr {
 		t.Errorf("client should have received error indicating the following gre interface `%s`was not found. Instead error was nil", greifId)
 	}
@@ -0,0 +1,22 @@
+package mikrotik
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+var interfaceIpipMenu = &mikrotikMenu{
+	path: "/interface/ipip",
+	name: "ipip interface",
+	item: InterfaceIpip{},
+}
+
+func resourceInterfaceIpip() *schema.Resource {
+	r := interfaceIpipMenu.resource()
+	r.Importer.State = interfaceIpipMenu.importByName
+	return r
+}
+
+type InterfaceIpip struct {
+	Id string `mikrotik:".id"`
+	InterfaceFastPathTunnel
+}
@@ -0,0 +1,91 @@
+package mikrotik
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccMikrotikResourceInterfaceIpip_create(t *testing.T) {
+	resourceName := "mikrotik_interface_ipip.autotest"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckMenuItemDestroy(interfaceIpipMenu, "mikrotik_interface_ipip"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInterfaceIpip("198.51.100.2", ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMenuItemExists(interfaceIpipMenu, resourceName),
+					resource.TestCheckResourceAttr(resourceName, "remote_address", "198.51.100.2"),
+				),
+			},
+			{
+				Config: testAccInterfaceIpip("198.51.100.3", `keepalive = "5s,3"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMenuItemExists(interfaceIpipMenu, resourceName),
+					resource.TestCheckResourceAttr(resourceName, "remote_address", "198.51.100.3"),
+					resource.TestCheckResourceAttr(resourceName, "keepalive", "5s,3"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     "autotest-ipip",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccInterfaceIpip(remoteAddress, attributes string) string {
+	return fmt.Sprintf(`
+resource "mikrotik_interface_ipip" "autotest" {
+	name = "autotest-ipip"
+	remote_address = "%s"
+	comment = "autotest"
+	%s
+}
+`, remoteAddress, attributes)
+}
+
+func TestMikrotikResourceInterfaceIpip_lifecycle(t *testing.T) {
+	f := newFakeRouter()
+	provider := testFakeProvider(newFakeApiServer(t, f))
+	resourceName := "mikrotik_interface_ipip.autotest"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testFakeRouterEmpty(f, "/interface/ipip"),
+		Steps: []resource.TestStep{
+			{
+				Config: provider + testAccInterfaceIpip("198.51.100.2", ""),
+				Check: testFakeRouterItem(f, "/interface/ipip", resourceName, map[string]string{
+					"name":            "autotest-ipip",
+					"remote-address":  "198.51.100.2",
+					"allow-fast-path": "yes",
+					"dont-fragment":   "no",
+					"mtu":             "auto",
+				}),
+			},
+			{
+				Config: provider + testAccInterfaceIpip("198.51.100.3", `
+	keepalive = "5s,3"
+	allow_fast_path = false
+`),
+				Check: testFakeRouterLastCommand(f, "/interface/ipip/set", "=allow-fast-path=no", "=remote-address=198.51.100.3", "=keepalive=5s,3"),
+			},
+			{
+				Config: provider + testAccInterfaceIpip("198.51.100.3", `
+	keepalive = "5s,3"
+	allow_fast_path = false
+`),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     "autotest-ipip",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,34 @@
+package mikrotik
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+var interfaceListMenu = &mikrotikMenu{
+	path: "/interface/list",
+	name: "interface list",
+	item: InterfaceList{},
+}
+
+// resourceInterfaceList records the planned name of the list, like bridges,
+// so members of a list created in the same apply pass their plan time check.
+func resourceInterfaceList() *schema.Resource {
+	r := interfaceListMenu.resource()
+	r.Importer.State = interfaceListMenu.importByName
+	r.CustomizeDiff = interfaceListMenu.planName
+	return r
+}
+
+// InterfaceList is a named list of interfaces for the interface list
+// matchers of firewall rules. Besides its members, a list holds the
+// interfaces of the lists it includes, minus those of the lists it
+// excludes.
+type InterfaceList struct {
+	Id      string   `mikrotik:".id"`
+	Name    string   `mikrotik:"name,required"`
+	Include []string `mikrotik:"include,set"`
+	Exclude []string `mikrotik:"exclude,set"`
+	Comment string   `mikrotik:"comment"`
+	Builtin bool     `mikrotik:"builtin,readonly"`
+	Dynamic bool     `mikrotik:"dynamic,readonly"`
+}
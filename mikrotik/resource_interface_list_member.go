@@ -0,0 +1,26 @@
+package mikrotik
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+var interfaceListMemberMenu = &mikrotikMenu{
+	path: "/interface/list/member",
+	name: "interface list member",
+	item: InterfaceListMember{},
+}
+
+func resourceInterfaceListMember() *schema.Resource {
+	r := interfaceListMemberMenu.resource()
+	r.CustomizeDiff = interfaceListMenu.requireExisting("list")
+	return r
+}
+
+type InterfaceListMember struct {
+	Id        string `mikrotik:".id"`
+	List      string `mikrotik:"list,required"`
+	Interface string `mikrotik:"interface,required"`
+	Comment   string `mikrotik:"comment"`
+	Disabled  bool   `mikrotik:"disabled,default=false"`
+	Dynamic   bool   `mikrotik:"dynamic,readonly"`
+}
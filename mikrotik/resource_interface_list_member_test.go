@@ -0,0 +1,149 @@
+package mikrotik
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccMikrotikResourceInterfaceListMember_create(t *testing.T) {
+	resourceName := "mikrotik_interface_list_member.autotest"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckMenuItemDestroy(interfaceListMemberMenu, "mikrotik_interface_list_member"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInterfaceListMember("ether1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMenuItemExists(interfaceListMemberMenu, resourceName),
+					resource.TestCheckResourceAttr(resourceName, "list", "autotest-list"),
+					resource.TestCheckResourceAttr(resourceName, "interface", "ether1"),
+				),
+			},
+			{
+				Config: testAccInterfaceListMember("ether2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMenuItemExists(interfaceListMemberMenu, resourceName),
+					resource.TestCheckResourceAttr(resourceName, "interface", "ether2"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// testAccInterfaceListMember declares a list, a member of it and a filter
+// rule matching the list, all in one configuration.
+func testAccInterfaceListMember(ifname string) string {
+	return fmt.Sprintf(`
+resource "mikrotik_interface_list" "autotest" {
+	name = "autotest-list"
+}
+
+resource "mikrotik_interface_list_member" "autotest" {
+	list = mikrotik_interface_list.autotest.name
+	interface = "%s"
+	comment = "autotest"
+}
+
+resource "mikrotik_ip_firewall_filter" "autotest" {
+	chain = "input"
+	action = "drop"
+	in_interface_list = mikrotik_interface_list.autotest.name
+	comment = "autotest"
+}
+`, ifname)
+}
+
+func TestMikrotikResourceInterfaceListMember_lifecycle(t *testing.T) {
+	f := newFakeRouter()
+	provider := testFakeProvider(newFakeApiServer(t, f))
+	resourceName := "mikrotik_interface_list_member.autotest"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: testAccProviders,
+		CheckDestroy: resource.ComposeAggregateTestCheckFunc(
+			testFakeRouterEmpty(f, "/interface/list/member"),
+			testFakeRouterEmpty(f, "/interface/list"),
+		),
+		Steps: []resource.TestStep{
+			{
+				// the list is created in the same apply
+				Config: provider + testAccInterfaceListMember("ether1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testFakeRouterItem(f, "/interface/list/member", resourceName, map[string]string{
+						"list":      "autotest-list",
+						"interface": "ether1",
+						"disabled":  "no",
+					}),
+					testFakeRouterItem(f, "/ip/firewall/filter", "mikrotik_ip_firewall_filter.autotest", map[string]string{
+						"in-interface-list": "autotest-list",
+					}),
+				),
+			},
+			{
+				Config: provider + testAccInterfaceListMember("ether2"),
+				Check:  testFakeRouterLastCommand(f, "/interface/list/member/set", "=interface=ether2"),
+			},
+			{
+				Config:            provider + testAccInterfaceListMember("ether2"),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccInterfaceListMemberOf(list string) string {
+	return fmt.Sprintf(`
+resource "mikrotik_interface_list_member" "autotest" {
+	list = "%s"
+	interface = "ether1"
+}
+`, list)
+}
+
+func TestMikrotikResourceInterfaceListMember_existingList(t *testing.T) {
+	f := newFakeRouter()
+	f.tables["/interface/list"] = []map[string]string{
+		{".id": "*2000010", "name": "WAN"},
+	}
+	provider := testFakeProvider(newFakeApiServer(t, f))
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testFakeRouterEmpty(f, "/interface/list/member"),
+		Steps: []resource.TestStep{
+			{
+				Config: provider + testAccInterfaceListMemberOf("WAN"),
+				Check: testFakeRouterItem(f, "/interface/list/member", "mikrotik_interface_list_member.autotest", map[string]string{
+					"list": "WAN",
+				}),
+			},
+		},
+	})
+}
+
+func TestMikrotikResourceInterfaceListMember_unknownList(t *testing.T) {
+	f := newFakeRouter()
+	provider := testFakeProvider(newFakeApiServer(t, f))
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testFakeRouterEmpty(f, "/interface/list/member"),
+		Steps: []resource.TestStep{
+			{
+				Config:      provider + testAccInterfaceListMemberOf("WNA"),
+				ExpectError: regexp.MustCompile("list references the interface list `WNA`, which does not exist"),
+			},
+		},
+	})
+}
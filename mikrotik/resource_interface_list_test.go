@@ -0,0 +1,92 @@
+package mikrotik
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccMikrotikResourceInterfaceList_create(t *testing.T) {
+	resourceName := "mikrotik_interface_list.autotest"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckMenuItemDestroy(interfaceListMenu, "mikrotik_interface_list"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInterfaceList(`include = ["static"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMenuItemExists(interfaceListMenu, resourceName),
+					resource.TestCheckResourceAttr(resourceName, "include.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "builtin", "false"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     "autotest-list",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccInterfaceList(attributes string) string {
+	return fmt.Sprintf(`
+resource "mikrotik_interface_list" "autotest" {
+	name = "autotest-list"
+	comment = "autotest"
+	%s
+}
+`, attributes)
+}
+
+func TestMikrotikResourceInterfaceList_lifecycle(t *testing.T) {
+	f := newFakeRouter()
+	provider := testFakeProvider(newFakeApiServer(t, f))
+	resourceName := "mikrotik_interface_list.autotest"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testFakeRouterEmpty(f, "/interface/list"),
+		Steps: []resource.TestStep{
+			{
+				Config: provider + testAccInterfaceList(""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testFakeRouterLastCommand(f, "/interface/list/add", "=name=autotest-list", "=comment=autotest"),
+					resource.TestCheckResourceAttr(resourceName, "include.#", "0"),
+				),
+			},
+			{
+				Config: provider + testAccInterfaceList(`
+	include = ["static", "LAN"]
+	exclude = ["dynamic"]
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testFakeRouterLastCommand(f, "/interface/list/set", "=include=LAN,static", "=exclude=dynamic"),
+					resource.TestCheckResourceAttr(resourceName, "include.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "exclude.#", "1"),
+				),
+			},
+			{
+				Config: provider + testAccInterfaceList(`
+	include = ["static"]
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testFakeRouterLastCommand(f, "/interface/list/set", "=include=static"),
+					testFakeRouterUnset(f, "/interface/list", "exclude"),
+				),
+			},
+			{
+				Config: provider + testAccInterfaceList(`
+	include = ["static"]
+`),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     "autotest-list",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,37 @@
+package mikrotik
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+var interfaceVlanMenu = &mikrotikMenu{
+	path: "/interface/vlan",
+	name: "vlan interface",
+	item: InterfaceVlan{},
+}
+
+// interfaceArpModes are the arp modes of an interface.
+var interfaceArpModes = []string{"disabled", "enabled", "local-proxy-arp", "proxy-arp", "reply-only"}
+
+func resourceInterfaceVlan() *schema.Resource {
+	r := interfaceVlanMenu.resource()
+	r.Importer.State = interfaceVlanMenu.importByName
+	r.Schema["vlan_id"].ValidateFunc = validation.IntBetween(1, 4094)
+	r.Schema["arp"].ValidateFunc = validation.StringInSlice(interfaceArpModes, false)
+	return r
+}
+
+type InterfaceVlan struct {
+	Id              string `mikrotik:".id"`
+	Name            string `mikrotik:"name,required"`
+	Vlan_id         int    `mikrotik:"vlan-id,required"`
+	Interface       string `mikrotik:"interface,required"`
+	Mtu             int    `mikrotik:"mtu,computed"`
+	Arp             string `mikrotik:"arp,default=enabled"`
+	Use_service_tag bool   `mikrotik:"use-service-tag,default=false"`
+	Comment         string `mikrotik:"comment"`
+	Disabled        bool   `mikrotik:"disabled,default=false"`
+	Mac_address     string `mikrotik:"mac-address,readonly"`
+	Running         bool   `mikrotik:"running,readonly"`
+}
@@ -0,0 +1,146 @@
+package mikrotik
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccMikrotikResourceInterfaceVlan_create(t *testing.T) {
+	resourceName := "mikrotik_interface_vlan.autotest"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckMenuItemDestroy(interfaceVlanMenu, "mikrotik_interface_vlan"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInterfaceVlan(99, `mtu = 1400`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMenuItemExists(interfaceVlanMenu, resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", "autotest-vlan"),
+					resource.TestCheckResourceAttr(resourceName, "vlan_id", "99"),
+					resource.TestCheckResourceAttr(resourceName, "mtu", "1400"),
+				),
+			},
+			{
+				Config: testAccInterfaceVlan(100, `arp = "reply-only"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMenuItemExists(interfaceVlanMenu, resourceName),
+					resource.TestCheckResourceAttr(resourceName, "vlan_id", "100"),
+					resource.TestCheckResourceAttr(resourceName, "arp", "reply-only"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     "autotest-vlan",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccInterfaceVlan(vlanId int, attributes string) string {
+	return fmt.Sprintf(`
+resource "mikrotik_interface_vlan" "autotest" {
+	name = "autotest-vlan"
+	vlan_id = %d
+	interface = "ether1"
+	comment = "autotest"
+	%s
+}
+`, vlanId, attributes)
+}
+
+func TestMikrotikResourceInterfaceVlan_lifecycle(t *testing.T) {
+	f := newFakeRouter()
+	provider := testFakeProvider(newFakeApiServer(t, f))
+	resourceName := "mikrotik_interface_vlan.autotest"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testFakeRouterEmpty(f, "/interface/vlan"),
+		Steps: []resource.TestStep{
+			{
+				Config: provider + testAccInterfaceVlan(99, `mtu = 1400`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testFakeRouterLastCommand(f, "/interface/vlan/add", "=name=autotest-vlan", "=vlan-id=99", "=interface=ether1", "=mtu=1400", "=arp=enabled", "=use-service-tag=no", "=comment=autotest", "=disabled=no"),
+					resource.TestCheckResourceAttr(resourceName, "vlan_id", "99"),
+					resource.TestCheckResourceAttr(resourceName, "use_service_tag", "false"),
+				),
+			},
+			{
+				Config: provider + testAccInterfaceVlan(100, `
+	mtu = 1400
+	arp = "proxy-arp"
+	use_service_tag = true
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testFakeRouterLastCommand(f, "/interface/vlan/set", "=vlan-id=100", "=arp=proxy-arp", "=use-service-tag=yes"),
+					testFakeRouterItem(f, "/interface/vlan", resourceName, map[string]string{
+						"name":            "autotest-vlan",
+						"vlan-id":         "100",
+						"use-service-tag": "yes",
+					}),
+				),
+			},
+			{
+				Config: provider + testAccInterfaceVlan(100, `
+	mtu = 1400
+	arp = "proxy-arp"
+	use_service_tag = true
+`),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     "autotest-vlan",
+				ImportStateVerify: true,
+			},
+			{
+				Config: provider + testAccInterfaceVlan(100, `
+	mtu = 1400
+	arp = "proxy-arp"
+	use_service_tag = true
+`),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestMikrotikResourceInterfaceVlan_importUnknownName(t *testing.T) {
+	f := newFakeRouter()
+	provider := testFakeProvider(newFakeApiServer(t, f))
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:        provider + testAccInterfaceVlan(99, ""),
+				ResourceName:  "mikrotik_interface_vlan.autotest",
+				ImportState:   true,
+				ImportStateId: "missing-vlan",
+				ExpectError:   regexp.MustCompile("vlan interface `missing-vlan` not found"),
+			},
+		},
+	})
+}
+
+func TestMikrotikResourceInterfaceVlan_invalidVlanId(t *testing.T) {
+	f := newFakeRouter()
+	provider := testFakeProvider(newFakeApiServer(t, f))
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testFakeRouterEmpty(f, "/interface/vlan"),
+		Steps: []resource.TestStep{
+			{
+				Config:      provider + testAccInterfaceVlan(4095, ""),
+				ExpectError: regexp.MustCompile(`expected vlan_id to be in the range \(1 - 4094\)`),
+			},
+		},
+	})
+}
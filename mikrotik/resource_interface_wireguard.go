@@ -0,0 +1,43 @@
+package mikrotik
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+var interfaceWireguardMenu = &mikrotikMenu{
+	path: "/interface/wireguard",
+	name: "wireguard interface",
+	item: InterfaceWireguard{},
+}
+
+// resourceInterfaceWireguard leaves the private key to the router when it is
+// not configured. The generated key is read back, like the public key
+// derived from it, so it is kept when the interface is updated. Changing the
+// private key leaves the public key unknown until it is applied, so that
+// peers referencing it are planned with the new one.
+func resourceInterfaceWireguard() *schema.Resource {
+	r := interfaceWireguardMenu.resource()
+	r.Importer.State = interfaceWireguardMenu.importByName
+	r.CustomizeDiff = customdiff.All(
+		interfaceWireguardMenu.planName,
+		customdiff.ComputedIf("public_key", func(d *schema.ResourceDiff, m interface{}) bool {
+			return d.HasChange("private_key")
+		}),
+	)
+	r.Schema["listen_port"].ValidateFunc = validation.IntBetween(1, 65535)
+	return r
+}
+
+type InterfaceWireguard struct {
+	Id          string `mikrotik:".id"`
+	Name        string `mikrotik:"name,required"`
+	Listen_port int    `mikrotik:"listen-port,computed"`
+	Mtu         int    `mikrotik:"mtu,default=1420"`
+	Private_key string `mikrotik:"private-key,computed,sensitive"`
+	Comment     string `mikrotik:"comment"`
+	Disabled    bool   `mikrotik:"disabled,default=false"`
+	Public_key  string `mikrotik:"public-key,readonly"`
+	Running     bool   `mikrotik:"running,readonly"`
+}
@@ -0,0 +1,39 @@
+package mikrotik
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+var interfaceWireguardPeerMenu = &mikrotikMenu{
+	path: "/interface/wireguard/peers",
+	name: "wireguard peer",
+	item: InterfaceWireguardPeer{},
+}
+
+func resourceInterfaceWireguardPeer() *schema.Resource {
+	r := interfaceWireguardPeerMenu.resource()
+	r.CustomizeDiff = interfaceWireguardMenu.requireExisting("interface")
+	r.Schema["allowed_address"].Elem.(*schema.Schema).ValidateFunc = validateIpPrefix
+	r.Schema["endpoint_port"].ValidateFunc = validation.IntBetween(1, 65535)
+	return r
+}
+
+// InterfaceWireguardPeer is a peer of a wireguard interface. The router
+// prints the allowed addresses with their prefix length, which is why they
+// must be configured with one.
+type InterfaceWireguardPeer struct {
+	Id                       string   `mikrotik:".id"`
+	Interface                string   `mikrotik:"interface,required"`
+	Public_key               string   `mikrotik:"public-key,required"`
+	Preshared_key            string   `mikrotik:"preshared-key,sensitive"`
+	Allowed_address          []string `mikrotik:"allowed-address,required,set"`
+	Endpoint_address         string   `mikrotik:"endpoint-address"`
+	Endpoint_port            int      `mikrotik:"endpoint-port,computed"`
+	Persistent_keepalive     string   `mikrotik:"persistent-keepalive"`
+	Comment                  string   `mikrotik:"comment"`
+	Disabled                 bool     `mikrotik:"disabled,default=false"`
+	Current_endpoint_address string   `mikrotik:"current-endpoint-address,readonly"`
+	Current_endpoint_port    int      `mikrotik:"current-endpoint-port,readonly"`
+	Last_handshake           string   `mikrotik:"last-handshake,readonly"`
+}
@@ -0,0 +1,160 @@
+package mikrotik
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccMikrotikResourceInterfaceWireguardPeer_create(t *testing.T) {
+	resourceName := "mikrotik_interface_wireguard_peer.autotest"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckMenuItemDestroy(interfaceWireguardPeerMenu, "mikrotik_interface_wireguard_peer"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInterfaceWireguardPeer(`
+	allowed_address = ["10.99.0.2/32", "192.168.99.0/24"]
+	endpoint_address = "198.51.100.7"
+	endpoint_port = 51820
+	persistent_keepalive = "25s"
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMenuItemExists(interfaceWireguardPeerMenu, resourceName),
+					resource.TestCheckResourceAttr(resourceName, "allowed_address.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "endpoint_port", "51820"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccInterfaceWireguardPeer(attributes string) string {
+	return fmt.Sprintf(`
+resource "mikrotik_interface_wireguard" "autotest" {
+	name = "autotest-wg"
+}
+
+resource "mikrotik_interface_wireguard_peer" "autotest" {
+	interface = mikrotik_interface_wireguard.autotest.name
+	public_key = "d29ybGQtd29ybGQtd29ybGQtd29ybGQtd29ybGQtMTI="
+	comment = "autotest"
+	%s
+}
+`, attributes)
+}
+
+func TestMikrotikResourceInterfaceWireguardPeer_lifecycle(t *testing.T) {
+	f := newFakeRouter()
+	provider := testFakeProvider(newFakeApiServer(t, f))
+	resourceName := "mikrotik_interface_wireguard_peer.autotest"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testFakeRouterEmpty(f, "/interface/wireguard/peers"),
+		Steps: []resource.TestStep{
+			{
+				Config: provider + testAccInterfaceWireguardPeer(`
+	preshared_key = "c2VjcmV0LXNlY3JldC1zZWNyZXQtc2VjcmV0LTEyMzQ="
+	allowed_address = ["10.99.0.2/32"]
+	endpoint_address = "198.51.100.7"
+	endpoint_port = 51820
+	persistent_keepalive = "25s"
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testFakeRouterItem(f, "/interface/wireguard/peers", resourceName, map[string]string{
+						"interface":            "autotest-wg",
+						"public-key":           "d29ybGQtd29ybGQtd29ybGQtd29ybGQtd29ybGQtMTI=",
+						"preshared-key":        "c2VjcmV0LXNlY3JldC1zZWNyZXQtc2VjcmV0LTEyMzQ=",
+						"allowed-address":      "10.99.0.2/32",
+						"endpoint-address":     "198.51.100.7",
+						"endpoint-port":        "51820",
+						"persistent-keepalive": "25s",
+					}),
+				),
+			},
+			{
+				// the router reports where the peer connects from
+				PreConfig: func() {
+					for _, item := range f.tables["/interface/wireguard/peers"] {
+						item["current-endpoint-address"] = "198.51.100.8"
+						item["current-endpoint-port"] = "40000"
+						item["last-handshake"] = "12s"
+					}
+				},
+				Config: provider + testAccInterfaceWireguardPeer(`
+	allowed_address = ["10.99.0.2/32", "192.168.99.0/24"]
+	endpoint_address = "198.51.100.7"
+	endpoint_port = 51820
+	persistent_keepalive = "25s"
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testFakeRouterLastCommand(f, "/interface/wireguard/peers/set", "=allowed-address=10.99.0.2/32,192.168.99.0/24"),
+					testFakeRouterUnset(f, "/interface/wireguard/peers", "preshared-key"),
+					resource.TestCheckResourceAttr(resourceName, "current_endpoint_address", "198.51.100.8"),
+					resource.TestCheckResourceAttr(resourceName, "current_endpoint_port", "40000"),
+					resource.TestCheckResourceAttr(resourceName, "last_handshake", "12s"),
+				),
+			},
+			{
+				Config: provider + testAccInterfaceWireguardPeer(`
+	allowed_address = ["10.99.0.2/32", "192.168.99.0/24"]
+	endpoint_address = "198.51.100.7"
+	endpoint_port = 51820
+	persistent_keepalive = "25s"
+`),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestMikrotikResourceInterfaceWireguardPeer_invalidAllowedAddress(t *testing.T) {
+	f := newFakeRouter()
+	provider := testFakeProvider(newFakeApiServer(t, f))
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testFakeRouterEmpty(f, "/interface/wireguard/peers"),
+		Steps: []resource.TestStep{
+			{
+				Config: provider + testAccInterfaceWireguardPeer(`
+	allowed_address = ["10.99.0.2"]
+`),
+				ExpectError: regexp.MustCompile(`expected allowed_address.\d+ to be an address with a prefix length`),
+			},
+		},
+	})
+}
+
+func TestMikrotikResourceInterfaceWireguardPeer_unknownInterface(t *testing.T) {
+	f := newFakeRouter()
+	provider := testFakeProvider(newFakeApiServer(t, f))
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testFakeRouterEmpty(f, "/interface/wireguard/peers"),
+		Steps: []resource.TestStep{
+			{
+				Config: provider + `
+resource "mikrotik_interface_wireguard_peer" "autotest" {
+	interface = "wg-missing"
+	public_key = "d29ybGQtd29ybGQtd29ybGQtd29ybGQtd29ybGQtMTI="
+	allowed_address = ["10.99.0.2/32"]
+}
+`,
+				ExpectError: regexp.MustCompile("interface references the wireguard interface `wg-missing`, which does not exist"),
+			},
+		},
+	})
+}
@@ -0,0 +1,156 @@
+package mikrotik
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccMikrotikResourceInterfaceWireguard_create(t *testing.T) {
+	resourceName := "mikrotik_interface_wireguard.autotest"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckMenuItemDestroy(interfaceWireguardMenu, "mikrotik_interface_wireguard"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInterfaceWireguard(`listen_port = 13231`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMenuItemExists(interfaceWireguardMenu, resourceName),
+					resource.TestCheckResourceAttrSet(resourceName, "private_key"),
+					resource.TestCheckResourceAttrSet(resourceName, "public_key"),
+					resource.TestCheckResourceAttr(resourceName, "listen_port", "13231"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     "autotest-wg",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccInterfaceWireguard(attributes string) string {
+	return fmt.Sprintf(`
+resource "mikrotik_interface_wireguard" "autotest" {
+	name = "autotest-wg"
+	comment = "autotest"
+	%s
+}
+`, attributes)
+}
+
+func TestMikrotikResourceInterfaceWireguard_generatedKey(t *testing.T) {
+	f := newFakeRouter()
+	provider := testFakeProvider(newFakeApiServer(t, f))
+	resourceName := "mikrotik_interface_wireguard.autotest"
+
+	// the router generates the private key of a new interface
+	generate := func() {
+		for _, item := range f.tables["/interface/wireguard"] {
+			item["private-key"] = "aGVsbG8taGVsbG8taGVsbG8taGVsbG8taGVsbG8tMTI="
+			item["public-key"] = "d29ybGQtd29ybGQtd29ybGQtd29ybGQtd29ybGQtMTI="
+			item["listen-port"] = "13231"
+		}
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testFakeRouterEmpty(f, "/interface/wireguard"),
+		Steps: []resource.TestStep{
+			{
+				Config: provider + testAccInterfaceWireguard(""),
+				Check:  testFakeRouterLastCommand(f, "/interface/wireguard/add", "=name=autotest-wg", "=mtu=1420", "=comment=autotest", "=disabled=no"),
+			},
+			{
+				PreConfig: generate,
+				Config:    provider + testAccInterfaceWireguard(""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "private_key", "aGVsbG8taGVsbG8taGVsbG8taGVsbG8taGVsbG8tMTI="),
+					resource.TestCheckResourceAttr(resourceName, "public_key", "d29ybGQtd29ybGQtd29ybGQtd29ybGQtd29ybGQtMTI="),
+					resource.TestCheckResourceAttr(resourceName, "listen_port", "13231"),
+				),
+			},
+			{
+				Config: provider + testAccInterfaceWireguard(`mtu = 1380`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testFakeRouterLastCommand(f, "/interface/wireguard/set", "=mtu=1380"),
+					resource.TestCheckResourceAttr(resourceName, "private_key", "aGVsbG8taGVsbG8taGVsbG8taGVsbG8taGVsbG8tMTI="),
+				),
+			},
+			{
+				Config:            provider + testAccInterfaceWireguard(`mtu = 1380`),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     "autotest-wg",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestMikrotikResourceInterfaceWireguard_configuredKey(t *testing.T) {
+	f := newFakeRouter()
+	provider := testFakeProvider(newFakeApiServer(t, f))
+	resourceName := "mikrotik_interface_wireguard.autotest"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testFakeRouterEmpty(f, "/interface/wireguard"),
+		Steps: []resource.TestStep{
+			{
+				Config: provider + testAccInterfaceWireguard(`
+	private_key = "aGVsbG8taGVsbG8taGVsbG8taGVsbG8taGVsbG8tMTI="
+	listen_port = 51820
+`),
+				Check: testFakeRouterItem(f, "/interface/wireguard", resourceName, map[string]string{
+					"private-key": "aGVsbG8taGVsbG8taGVsbG8taGVsbG8taGVsbG8tMTI=",
+					"listen-port": "51820",
+				}),
+			},
+		},
+	})
+}
+
+func TestMikrotikResourceInterfaceWireguard_keyRotation(t *testing.T) {
+	f := newFakeRouter()
+	provider := testFakeProvider(newFakeApiServer(t, f))
+	// a peer on the interface itself stands in for the remote end
+	config := func(privateKey string) string {
+		return provider + testAccInterfaceWireguard(fmt.Sprintf(`private_key = %q`, privateKey)) + `
+resource "mikrotik_interface_wireguard_peer" "autotest" {
+	interface = mikrotik_interface_wireguard.autotest.name
+	public_key = mikrotik_interface_wireguard.autotest.public_key
+	allowed_address = ["10.99.0.2/32"]
+}
+`
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testFakeRouterEmpty(f, "/interface/wireguard"),
+		Steps: []resource.TestStep{
+			{
+				Config: config("aGVsbG8taGVsbG8taGVsbG8taGVsbG8taGVsbG8tMTI="),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mikrotik_interface_wireguard.autotest", "public_key", fakePublicKey("aGVsbG8taGVsbG8taGVsbG8taGVsbG8taGVsbG8tMTI=")),
+					testFakeRouterItem(f, "/interface/wireguard/peers", "mikrotik_interface_wireguard_peer.autotest", map[string]string{
+						"public-key": fakePublicKey("aGVsbG8taGVsbG8taGVsbG8taGVsbG8taGVsbG8tMTI="),
+					}),
+				),
+			},
+			{
+				Config: config("cm90YXRlZC1yb3RhdGVkLXJvdGF0ZWQtcm90YXRlZDE="),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mikrotik_interface_wireguard.autotest", "public_key", fakePublicKey("cm90YXRlZC1yb3RhdGVkLXJvdGF0ZWQtcm90YXRlZDE=")),
+					testFakeRouterItem(f, "/interface/wireguard/peers", "mikrotik_interface_wireguard_peer.autotest", map[string]string{
+						"public-key": fakePublicKey("cm90YXRlZC1yb3RhdGVkLXJvdGF0ZWQtcm90YXRlZDE="),
+					}),
+				),
+			},
+		},
+	})
+}
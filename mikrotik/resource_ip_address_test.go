@@ -3,6 +3,7 @@ package mikrotik
 import (
 	"fmt"
 	"reflect"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -152,7 +153,7 @@ func testAccCheckMikrotikIpAddressDestroy(s *terraform.State) error {
 }
 
 func TestAccMikrotikResourceIpAddress_add_delete(t *testing.T) {
-	c := NewClient(GetConfigFromEnv())
+	c := testAccClient(t)
 
 	address := "1.1.1.1/24"
 	ifname := "ether1"
@@ -205,7 +206,7 @@ func TestAccMikrotikResourceIpAddress_add_delete(t *testing.T) {
 }
 
 func TestAccMikrotikResourceIpAddress_add_update_delete(t *testing.T) {
-	c := NewClient(GetConfigFromEnv())
+	c := testAccClient(t)
 
 	initialAddress := "1.1.1.1/24"
 	updatedAddress := "1.1.1.2/24"
@@ -267,13 +268,147 @@ func TestAccMikrotikResourceIpAddress_add_update_delete(t *testing.T) {
 }
 
 func TestAccMikrotikResourceIpAddress_find_nonexisting(t *testing.T) {
-	c := NewClient(GetConfigFromEnv())
+	c := testAccClient(t)
 
 	ipaddrId := "Invalid id"
 	_, err := c.FindIpAddress(ipaddrId)
 
-	expectedErrStr := fmt.Sprintf("ip address `%s`not found", ipaddrId)
+	expectedErrStr := fmt.Sprintf("ip address `%s` not found", ipaddrId)
 	if err == nil || err.Error() != expectedErrStr {
 		t.Errorf("client should have received error indicating the following ip address `%s`was not found. Instead error was nil", ipaddrId)
 	}
 }
+
+func TestMikrotikResourceIpAddress_lifecycle(t *testing.T) {
+	f := newFakeRouter()
+	provider := testFakeProvider(newFakeApiServer(t, f))
+	resourceName := "mikrotik_ip_address.autotest"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testFakeRouterEmpty(f, "/ip/address"),
+		Steps: []resource.TestStep{
+			{
+				Config: provider + testAccIpAddress(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testFakeRouterItem(f, "/ip/address", resourceName, map[string]string{
+						"address":   originalIpAddress,
+						"interface": originalInterface,
+					}),
+					resource.TestCheckResourceAttr(resourceName, "address", originalIpAddress),
+				),
+			},
+			{
+				Config: provider + testAccIpAddressUpdateAddress(),
+				Check: testFakeRouterItem(f, "/ip/address", resourceName, map[string]string{
+					"address":   updatedIpAddress,
+					"interface": originalInterface,
+				}),
+			},
+			{
+				Config: provider + testAccIpAddressUpdateInterface(),
+				Check: testFakeRouterItem(f, "/ip/address", resourceName, map[string]string{
+					"address":   updatedIpAddress,
+					"interface": updatedInterface,
+				}),
+			},
+			{
+				Config:            provider + testAccIpAddressUpdateInterface(),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccIpAddressAttributes(address, attributes string) string {
+	return fmt.Sprintf(`
+resource "mikrotik_ip_address" "autotest" {
+	address = "%s"
+	interface = "ether1"
+	%s
+}
+`, address, attributes)
+}
+
+func TestMikrotikResourceIpAddress_attributes(t *testing.T) {
+	f := newFakeRouter()
+	provider := testFakeProvider(newFakeApiServer(t, f))
+	resourceName := "mikrotik_ip_address.autotest"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testFakeRouterEmpty(f, "/ip/address"),
+		Steps: []resource.TestStep{
+			{
+				Config: provider + testAccIpAddressAttributes("192.168.88.1/24", `
+	comment = "lan"
+	disabled = true
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testFakeRouterLastCommand(f, "/ip/address/add", "=address=192.168.88.1/24", "=interface=ether1", "=comment=lan", "=disabled=yes"),
+					resource.TestCheckResourceAttr(resourceName, "comment", "lan"),
+					resource.TestCheckResourceAttr(resourceName, "disabled", "true"),
+				),
+			},
+			{
+				// the router computes the broadcast and reports the flags
+				PreConfig: func() {
+					for _, item := range f.tables["/ip/address"] {
+						item["network"] = "192.168.88.0"
+						item["broadcast"] = "192.168.88.255"
+						item["actual-interface"] = "bridge1"
+						item["invalid"] = "true"
+						item["dynamic"] = "false"
+					}
+				},
+				Config: provider + testAccIpAddressAttributes("192.168.88.1/24", `
+	comment = "lan"
+	disabled = true
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "network", "192.168.88.0"),
+					resource.TestCheckResourceAttr(resourceName, "broadcast", "192.168.88.255"),
+					resource.TestCheckResourceAttr(resourceName, "actual_interface", "bridge1"),
+					resource.TestCheckResourceAttr(resourceName, "invalid", "true"),
+					resource.TestCheckResourceAttr(resourceName, "dynamic", "false"),
+				),
+			},
+			{
+				Config: provider + testAccIpAddressAttributes("192.168.88.1/24", `
+	broadcast = "192.168.88.127"
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testFakeRouterLastCommand(f, "/ip/address/set", "=broadcast=192.168.88.127", "=disabled=no"),
+					testFakeRouterUnset(f, "/ip/address", "comment"),
+					resource.TestCheckResourceAttr(resourceName, "broadcast", "192.168.88.127"),
+				),
+			},
+			{
+				Config: provider + testAccIpAddressAttributes("192.168.88.1/24", `
+	broadcast = "192.168.88.127"
+`),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestMikrotikResourceIpAddress_invalidAddress(t *testing.T) {
+	f := newFakeRouter()
+	provider := testFakeProvider(newFakeApiServer(t, f))
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testFakeRouterEmpty(f, "/ip/address"),
+		Steps: []resource.TestStep{
+			{
+				Config:      provider + testAccIpAddressAttributes("192.168.88.1", ""),
+				ExpectError: regexp.MustCompile(`expected address to be an IPv4 address with a prefix length`),
+			},
+		},
+	})
+}
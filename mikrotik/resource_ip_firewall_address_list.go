@@ -1,210 +1,92 @@
 package mikrotik
 
 import (
-	"fmt"
-	"log"
-	"strings"
-
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 )
 
-func resourceIpFirewallAddressList() *schema.Resource {
-	return &schema.Resource{
-		Create: resourceIpFirewallAddressListCreate,
-		Read:   resourceIpFirewallAddressListRead,
-		Update: resourceIpFirewallAddressListUpdate,
-		Delete: resourceIpFirewallAddressListDelete,
-		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
-		},
-
-		Schema: map[string]*schema.Schema{
-			"address": {
-				Type:     schema.TypeString,
-				Required: true,
-			},
-			"list": {
-				Type:     schema.TypeString,
-				Required: true,
-			},
-			"comment": {
-				Type:     schema.TypeString,
-				Optional: true,
-			},
-			"disabled": {
-				Type:     schema.TypeBool,
-				Optional: true,
-				Default:  false,
-			},
-		},
-	}
+var ipFirewallAddressListMenu = &mikrotikMenu{
+	path: "/ip/firewall/address-list",
+	name: "ip firewall address-list",
+	item: IpFirewallAddressList{},
 }
 
-func resourceIpFirewallAddressListCreate(d *schema.ResourceData, m interface{}) error {
-	address := d.Get("address").(string)
-	list := d.Get("list").(string)
-	comment := d.Get("comment").(string)
-	disabled := d.Get("disabled").(bool)
-
-	c := m.(mikrotikConfig)
-
-	addresslist, err := c.AddIpFirewallAddressList(address, list, comment, disabled)
-
-	if err != nil {
-		return err
-	}
-
-	writeStateIpFirewallAddressList(addresslist, d)
-	return nil
+func resourceIpFirewallAddressList() *schema.Resource {
+	r := ipFirewallAddressListMenu.resource()
+	r.Schema["timeout"].ValidateFunc = validateDuration
+	r.Schema["timeout"].DiffSuppressFunc = suppressTimeoutCountdown
+	return r
 }
 
-func resourceIpFirewallAddressListRead(d *schema.ResourceData, m interface{}) error {
-	c := m.(mikrotikConfig)
-
-	addresslist, err := c.FindIpFirewallAddressList(d.Id())
-
-	if err != nil {
-		d.SetId("")
-		return nil
-	}
-
-	if addresslist == nil {
-		d.SetId("")
-		return nil
+// suppressTimeoutCountdown ignores the difference between the configured
+// timeout of an entry and the time it has left, which the router prints
+// instead and counts down. As the time left cannot tell how the entry was
+// added, raising the timeout is only noticed once the entry has expired.
+func suppressTimeoutCountdown(k, old, new string, d *schema.ResourceData) bool {
+	if old == "" || new == "" {
+		return false
 	}
 
-	writeStateIpFirewallAddressList(addresslist, d)
-	return nil
-}
-
-func resourceIpFirewallAddressListUpdate(d *schema.ResourceData, m interface{}) error {
-	c := m.(mikrotikConfig)
-
-	address := d.Get("address").(string)
-	list := d.Get("list").(string)
-	comment := d.Get("comment").(string)
-	disabled := d.Get("disabled").(bool)
-
-	addresslist, err := c.UpdateIpFirewallAddressList(d.Id(), address, list, comment, disabled)
-
+	left, err := ttlToSeconds(old)
 	if err != nil {
-		return err
+		return false
 	}
-
-	writeStateIpFirewallAddressList(addresslist, d)
-	return nil
-}
-
-func resourceIpFirewallAddressListDelete(d *schema.ResourceData, m interface{}) error {
-	c := m.(mikrotikConfig)
-
-	err := c.DeleteIpFirewallAddressList(d.Id())
-
+	timeout, err := ttlToSeconds(new)
 	if err != nil {
-		return err
+		return false
 	}
-
-	d.SetId("")
-	return nil
-}
-
-func writeStateIpFirewallAddressList(addresslist *IpFirewallAddressList, d *schema.ResourceData) error {
-	d.SetId(addresslist.Id)
-	d.Set("address", addresslist.Address)
-	d.Set("list", addresslist.List)
-	d.Set("comment", addresslist.Comment)
-	d.Set("disabled", addresslist.Disabled)
-	return nil
+	return left > 0 && left <= timeout
 }
 
 type IpFirewallAddressList struct {
-	Id       string `mikrotik:".id"`
-	Address  string `mikrotik:".address"`
-	List     string `mikrotik:".list"`
-	Comment  string `mikrotik:".comment"`
-	Disabled bool   `mikrotik:".disabled"`
+	Id            string `mikrotik:".id"`
+	Address       string `mikrotik:"address,required"`
+	List          string `mikrotik:"list,required"`
+	Comment       string `mikrotik:"comment"`
+	Disabled      bool   `mikrotik:"disabled,default=false"`
+	Timeout       string `mikrotik:"timeout"`
+	Dynamic       bool   `mikrotik:"dynamic,readonly"`
+	Creation_time string `mikrotik:"creation-time,readonly"`
 }
 
-func (mikrotikClient mikrotikConfig) AddIpFirewallAddressList(address string, list string, comment string, disabled bool) (*IpFirewallAddressList, error) {
-	c, err := mikrotikClient.getMikrotikClient()
-
-	if err != nil {
-		return nil, err
-	}
-
-	cmd := []string{
-		"/ip/firewall/address-list/add",
-		fmt.Sprintf("=address=%s", address),
-		fmt.Sprintf("=list=%s", list),
-		fmt.Sprintf("=comment=%s", comment),
-		fmt.Sprintf("=disabled=%s", boolToMikrotikBool(disabled)),
+func (mikrotikClient mikrotikConfig) AddIpFirewallAddressList(address string, list string, comment string, disabled bool, timeout string) (*IpFirewallAddressList, error) {
+	addresslist := &IpFirewallAddressList{
+		Address:  address,
+		List:     list,
+		Comment:  comment,
+		Disabled: disabled,
+		Timeout:  timeout,
 	}
 
-	log.Printf("[INFO] Running the mikrotik command: `%s`", cmd)
-	r, err := c.RunArgs(cmd)
-
-	log.Printf("[DEBUG] ip firewall address-list creation response: `%v`", r)
+	id, err := mikrotikClient.addItem(ipFirewallAddressListMenu, addresslist)
 
 	if err != nil {
 		return nil, err
 	}
 
-	id := r.Done.Map["ret"]
-
 	return mikrotikClient.FindIpFirewallAddressList(id)
 }
 
 func (mikrotikClient mikrotikConfig) FindIpFirewallAddressList(id string) (*IpFirewallAddressList, error) {
-	c, err := mikrotikClient.getMikrotikClient()
-
-	if err != nil {
-		return nil, err
-	}
-
-	cmd := strings.Split(fmt.Sprintf("/ip/firewall/address-list/print ?.id=%s", id), " ")
-	log.Printf("[INFO] Running the mikrotik command: `%s`", cmd)
-	r, err := c.RunArgs(cmd)
-
-	log.Printf("[DEBUG] ip firewall address-list response: %v", r)
-
-	if err != nil {
-		return nil, err
-	}
-
-	addresslist := IpFirewallAddressList{}
-	err = Unmarshal(*r, &addresslist)
+	addresslist := &IpFirewallAddressList{}
+	err := mikrotikClient.findItem(ipFirewallAddressListMenu, id, addresslist)
 
 	if err != nil {
 		return nil, err
 	}
 
-	if addresslist.Id == "" {
-		return nil, NewNotFound(fmt.Sprintf("ip firewall address-list `%s`not found", id))
-	}
-
-	return &addresslist, nil
+	return addresslist, nil
 }
 
-func (mikrotikClient mikrotikConfig) UpdateIpFirewallAddressList(id string, address string, list string, comment string, disabled bool) (*IpFirewallAddressList, error) {
-	c, err := mikrotikClient.getMikrotikClient()
-
-	if err != nil {
-		return nil, err
-	}
-
-	cmd := []string{
-		"/ip/firewall/address-list/set",
-		fmt.Sprintf("=.id=%s", id),
-		fmt.Sprintf("=address=%s", address),
-		fmt.Sprintf("=list=%s", list),
-		fmt.Sprintf("=comment=%s", comment),
-		fmt.Sprintf("=disabled=%s", boolToMikrotikBool(disabled)),
+func (mikrotikClient mikrotikConfig) UpdateIpFirewallAddressList(id string, address string, list string, comment string, disabled bool, timeout string) (*IpFirewallAddressList, error) {
+	addresslist := &IpFirewallAddressList{
+		Address:  address,
+		List:     list,
+		Comment:  comment,
+		Disabled: disabled,
+		Timeout:  timeout,
 	}
 
-	log.Printf("[INFO] Running the mikrotik command: `%s`", cmd)
-	r, err := c.RunArgs(cmd)
-
-	log.Printf("[DEBUG] ip firewall address-list update response: `%v`", r)
+	err := mikrotikClient.setItem(ipFirewallAddressListMenu, id, addresslist)
 
 	if err != nil {
 		return nil, err
@@ -214,21 +96,5 @@ func (mikrotikClient mikrotikConfig) UpdateIpFirewallAddressList(id string, addr
 }
 
 func (mikrotikClient mikrotikConfig) DeleteIpFirewallAddressList(id string) error {
-	c, err := mikrotikClient.getMikrotikClient()
-
-	if err != nil {
-		return err
-	}
-
-	cmd := []string{
-		"/ip/firewall/address-list/remove",
-		fmt.Sprintf("=.id=%s", id),
-	}
-
-	log.Printf("[INFO] Running the mikrotik command: `%s`", cmd)
-	r, err := c.RunArgs(cmd)
-
-	log.Printf("[DEBUG] ip firewall address-list delete response: `%v`", r)
-
-	return err
+	return mikrotikClient.removeItem(ipFirewallAddressListMenu, id)
 }
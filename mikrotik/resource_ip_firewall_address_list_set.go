@@ -0,0 +1,284 @@
+package mikrotik
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// ipFirewallAddressListSetMenu lists the entries of an address list for
+// mikrotik_ip_firewall_address_list_set.
+var ipFirewallAddressListSetMenu = &mikrotikMenu{
+	path: "/ip/firewall/address-list",
+	name: "ip firewall address-list",
+	item: IpFirewallAddressListEntry{},
+}
+
+// IpFirewallAddressListEntry is an entry of a whole address list. The
+// timeout is only sent when the entry is added, as the router counts it
+// down from then on, and makes the entry dynamic.
+type IpFirewallAddressListEntry struct {
+	Id      string `mikrotik:".id"`
+	Address string `mikrotik:"address"`
+	List    string `mikrotik:"list"`
+	Comment string `mikrotik:"comment"`
+	Timeout string `mikrotik:"timeout,writeonly"`
+	Dynamic bool   `mikrotik:"dynamic,readonly"`
+}
+
+// resourceIpFirewallAddressListSet manages every entry of one address list.
+// Entries are matched by address, so applying a change only adds, removes
+// or comments the entries which differ, over the shared API session.
+//
+// The id of the resource is the name of the list, which is also what is
+// expected on import.
+func resourceIpFirewallAddressListSet() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceIpFirewallAddressListSetCreate,
+		Read:   resourceIpFirewallAddressListSetRead,
+		Update: resourceIpFirewallAddressListSetUpdate,
+		Delete: resourceIpFirewallAddressListSetDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Timeouts: resourceTimeouts(),
+
+		Schema: map[string]*schema.Schema{
+			"list": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"entry": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"address": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"comment": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"timeout": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateDuration,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceIpFirewallAddressListSetCreate(d *schema.ResourceData, m interface{}) error {
+	c := m.(mikrotikConfig).withTimeout(d.Timeout(schema.TimeoutCreate))
+
+	list := d.Get("list").(string)
+	if err := syncAddressListSet(d, c, list); err != nil {
+		return err
+	}
+
+	d.SetId(list)
+	return readAddressListSet(d, c)
+}
+
+func resourceIpFirewallAddressListSetRead(d *schema.ResourceData, m interface{}) error {
+	c := m.(mikrotikConfig).withTimeout(d.Timeout(schema.TimeoutRead))
+
+	return readAddressListSet(d, c)
+}
+
+func resourceIpFirewallAddressListSetUpdate(d *schema.ResourceData, m interface{}) error {
+	c := m.(mikrotikConfig).withTimeout(d.Timeout(schema.TimeoutUpdate))
+
+	if err := syncAddressListSet(d, c, d.Id()); err != nil {
+		return err
+	}
+
+	return readAddressListSet(d, c)
+}
+
+func resourceIpFirewallAddressListSetDelete(d *schema.ResourceData, m interface{}) error {
+	c := m.(mikrotikConfig).withTimeout(d.Timeout(schema.TimeoutDelete))
+
+	current, err := addressListEntries(c, d.Id(), addressListSetKeys(d.Get("entry").(*schema.Set)))
+	if err != nil {
+		return err
+	}
+
+	if len(current) > 0 {
+		ids := make([]string, len(current))
+		for i, entry := range current {
+			ids[i] = entry.Id
+		}
+		if err := c.removeItem(ipFirewallAddressListSetMenu, strings.Join(ids, ",")); err != nil {
+			return err
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// readAddressListSet reads the entries of the list. The timeout and the
+// spelling of the address are kept from the state, as the router prints
+// the remaining time and drops the /32 of single addresses.
+func readAddressListSet(d *schema.ResourceData, c mikrotikConfig) error {
+	entrySet := d.Get("entry").(*schema.Set)
+	current, err := addressListEntries(c, d.Id(), addressListSetKeys(entrySet))
+	if err != nil {
+		return err
+	}
+
+	known := map[string]map[string]interface{}{}
+	for _, entry := range entrySet.List() {
+		values := entry.(map[string]interface{})
+		known[addressListKey(values["address"].(string))] = values
+	}
+
+	entries := make([]interface{}, len(current))
+	for i, entry := range current {
+		values := map[string]interface{}{
+			"address": entry.Address,
+			"comment": entry.Comment,
+			"timeout": "",
+		}
+		if prior, ok := known[addressListKey(entry.Address)]; ok {
+			values["address"] = prior["address"]
+			values["timeout"] = prior["timeout"]
+		}
+		entries[i] = values
+	}
+
+	if err := d.Set("list", d.Id()); err != nil {
+		return err
+	}
+	return d.Set("entry", entries)
+}
+
+// syncAddressListSet adds the missing entries of list, updates the comment
+// of the existing ones and removes the entries which are not declared. An
+// entry whose timeout changed is removed and added again, as the timeout
+// cannot be set on an existing entry.
+func syncAddressListSet(d *schema.ResourceData, c mikrotikConfig, list string) error {
+	old, _ := d.GetChange("entry")
+	previous := map[string]string{}
+	for _, e := range old.(*schema.Set).List() {
+		values := e.(map[string]interface{})
+		previous[addressListKey(values["address"].(string))] = values["timeout"].(string)
+	}
+
+	declared := map[string]*IpFirewallAddressListEntry{}
+	for _, e := range d.Get("entry").(*schema.Set).List() {
+		values := e.(map[string]interface{})
+		entry := &IpFirewallAddressListEntry{
+			Address: values["address"].(string),
+			List:    list,
+			Comment: values["comment"].(string),
+			Timeout: values["timeout"].(string),
+		}
+
+		key := addressListKey(entry.Address)
+		if _, ok := declared[key]; ok {
+			return fmt.Errorf("address `%s` is declared more than once in list `%s`", key, list)
+		}
+		declared[key] = entry
+	}
+
+	owned := map[string]bool{}
+	for key := range previous {
+		owned[key] = true
+	}
+	for key := range declared {
+		owned[key] = true
+	}
+	current, err := addressListEntries(c, list, owned)
+	if err != nil {
+		return err
+	}
+
+	var stale []string
+	for _, found := range current {
+		key := addressListKey(found.Address)
+		entry, ok := declared[key]
+		if !ok {
+			stale = append(stale, found.Id)
+			continue
+		}
+
+		timeout, known := previous[key]
+		if (known && timeout != entry.Timeout) || (!known && found.Dynamic != (entry.Timeout != "")) {
+			stale = append(stale, found.Id)
+			continue
+		}
+		delete(declared, key)
+
+		if found.Comment != entry.Comment {
+			if err := c.setAttributes(ipFirewallAddressListSetMenu, found.Id, []string{"=comment=" + entry.Comment}); err != nil {
+				return err
+			}
+		}
+	}
+
+	// the entries replaced because of their timeout are removed before
+	// being added again, as the router refuses duplicate addresses
+	if len(stale) > 0 {
+		if err := c.removeItem(ipFirewallAddressListSetMenu, strings.Join(stale, ",")); err != nil {
+			return err
+		}
+	}
+
+	keys := make([]string, 0, len(declared))
+	for key := range declared {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if _, err := c.addItem(ipFirewallAddressListSetMenu, declared[key]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// addressListEntries returns the entries of list owned by the resource: the
+// static ones, and the dynamic ones whose address is among owned. Other
+// dynamic entries, such as those added by `add-src-to-address-list` rules,
+// are left alone.
+func addressListEntries(c mikrotikConfig, list string, owned map[string]bool) ([]*IpFirewallAddressListEntry, error) {
+	items, err := c.listItems(ipFirewallAddressListSetMenu, "?list="+list)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []*IpFirewallAddressListEntry
+	for _, item := range items {
+		entry := item.(*IpFirewallAddressListEntry)
+		if !entry.Dynamic || owned[addressListKey(entry.Address)] {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+// addressListSetKeys returns the addresses of the entries of a set.
+func addressListSetKeys(entries *schema.Set) map[string]bool {
+	keys := map[string]bool{}
+	for _, e := range entries.List() {
+		keys[addressListKey(e.(map[string]interface{})["address"].(string))] = true
+	}
+	return keys
+}
+
+// addressListKey is the address of an entry as printed by the router.
+func addressListKey(address string) string {
+	return strings.TrimSuffix(address, "/32")
+}
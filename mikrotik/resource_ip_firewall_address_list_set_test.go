@@ -0,0 +1,287 @@
+package mikrotik
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccMikrotikResourceIpFirewallAddressListSet_create(t *testing.T) {
+	resourceName := "mikrotik_ip_firewall_address_list_set.autotest"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIpFirewallAddressListSet(`
+	entry {
+		address = "198.51.100.1"
+		comment = "one"
+	}
+	entry {
+		address = "198.51.100.0/28"
+	}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", "autotest"),
+					resource.TestCheckResourceAttr(resourceName, "entry.#", "2"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccIpFirewallAddressListSet(entries string) string {
+	return fmt.Sprintf(`
+resource "mikrotik_ip_firewall_address_list_set" "autotest" {
+	list = "autotest"
+	%s
+}
+`, entries)
+}
+
+// testFakeRouterList returns a check that list has entries with the given
+// address=comment pairs on the fake router.
+func testFakeRouterList(f *fakeRouter, list string, entries ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		var actual []string
+		for _, item := range f.items("/ip/firewall/address-list") {
+			if item["list"] == list {
+				actual = append(actual, item["address"]+"="+item["comment"])
+			}
+		}
+		sort.Strings(actual)
+		sort.Strings(entries)
+		if strings.Join(actual, " ") != strings.Join(entries, " ") {
+			return fmt.Errorf("Expected the entries %v in %s, the router has %v", entries, list, actual)
+		}
+		return nil
+	}
+}
+
+func TestMikrotikResourceIpFirewallAddressListSet_lifecycle(t *testing.T) {
+	f := newFakeRouter()
+	f.tables["/ip/firewall/address-list"] = []map[string]string{
+		{".id": "*A0", "list": "other", "address": "192.0.2.1"},
+		{".id": "*A1", "list": "autotest", "address": "198.51.100.1", "comment": "old"},
+		{".id": "*A2", "list": "autotest", "address": "198.51.100.2"},
+		{".id": "*A3", "list": "autotest", "address": "198.51.100.3", "dynamic": "true", "timeout": "23h59m"},
+	}
+	provider := testFakeProvider(newFakeApiServer(t, f))
+	resourceName := "mikrotik_ip_firewall_address_list_set.autotest"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: testAccProviders,
+		CheckDestroy: func(s *terraform.State) error {
+			return testFakeRouterList(f, "autotest")(s)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: provider + testAccIpFirewallAddressListSet(`
+	entry {
+		address = "198.51.100.1/32"
+		comment = "one"
+	}
+	entry {
+		address = "198.51.100.3"
+		timeout = "1d"
+	}
+	entry {
+		address = "198.51.100.4"
+		timeout = "1h"
+	}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testFakeRouterList(f, "autotest", "198.51.100.1=one", "198.51.100.3=", "198.51.100.4="),
+					testFakeRouterList(f, "other", "192.0.2.1="),
+					testFakeRouterLastCommand(f, "/ip/firewall/address-list/set", "=comment=one"),
+					testFakeRouterLastCommand(f, "/ip/firewall/address-list/add", "=address=198.51.100.4", "=list=autotest", "=timeout=1h"),
+					testFakeRouterLastCommand(f, "/ip/firewall/address-list/remove"),
+					resource.TestCheckResourceAttr(resourceName, "id", "autotest"),
+					resource.TestCheckResourceAttr(resourceName, "entry.#", "3"),
+				),
+			},
+			{
+				// dropping the /32 changes nothing on the router
+				Config: provider + testAccIpFirewallAddressListSet(`
+	entry {
+		address = "198.51.100.1"
+		comment = "one"
+	}
+	entry {
+		address = "198.51.100.5"
+	}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testFakeRouterList(f, "autotest", "198.51.100.1=one", "198.51.100.5="),
+					testFakeRouterLastCommand(f, "/ip/firewall/address-list/add", "=address=198.51.100.5", "=list=autotest"),
+					testFakeRouterLastCommand(f, "/ip/firewall/address-list/set", "=comment=one"),
+				),
+			},
+			{
+				Config: provider + testAccIpFirewallAddressListSet(`
+	entry {
+		address = "198.51.100.1"
+		comment = "one"
+	}
+	entry {
+		address = "198.51.100.5"
+	}
+`),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     "autotest",
+				ImportStateVerify: true,
+			},
+		},
+	})
+
+	for _, cmd := range f.commands {
+		if cmd[0] == "/ip/firewall/address-list/add" && contains(cmd, "=address=198.51.100.3") {
+			t.Errorf("The existing entry 198.51.100.3 should not be added again: %v", cmd)
+		}
+	}
+}
+
+func TestMikrotikResourceIpFirewallAddressListSet_duplicateAddress(t *testing.T) {
+	f := newFakeRouter()
+	provider := testFakeProvider(newFakeApiServer(t, f))
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testFakeRouterEmpty(f, "/ip/firewall/address-list"),
+		Steps: []resource.TestStep{
+			{
+				Config: provider + testAccIpFirewallAddressListSet(`
+	entry {
+		address = "198.51.100.1"
+	}
+	entry {
+		address = "198.51.100.1/32"
+	}
+`),
+				ExpectError: regexp.MustCompile("address `198.51.100.1` is declared more than once in list `autotest`"),
+			},
+		},
+	})
+}
+
+func TestMikrotikResourceIpFirewallAddressListSet_foreignDynamicEntries(t *testing.T) {
+	f := newFakeRouter()
+	f.tables["/ip/firewall/address-list"] = []map[string]string{
+		{".id": "*A1", "list": "autotest", "address": "203.0.113.9", "dynamic": "true", "timeout": "59m"},
+	}
+	provider := testFakeProvider(newFakeApiServer(t, f))
+	config := provider + testAccIpFirewallAddressListSet(`
+	entry {
+		address = "198.51.100.1"
+	}
+`)
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: testAccProviders,
+		CheckDestroy: func(s *terraform.State) error {
+			return testFakeRouterList(f, "autotest", "203.0.113.9=", "203.0.113.10=")(s)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testFakeRouterList(f, "autotest", "198.51.100.1=", "203.0.113.9="),
+					resource.TestCheckResourceAttr("mikrotik_ip_firewall_address_list_set.autotest", "entry.#", "1"),
+				),
+			},
+			{
+				// entries added by firewall rules meanwhile are no drift
+				PreConfig: func() {
+					f.run([]string{"/ip/firewall/address-list/add", "=list=autotest", "=address=203.0.113.10", "=timeout=1h"})
+				},
+				Config:   config,
+				PlanOnly: true,
+			},
+			{
+				Config: config,
+				Check:  testFakeRouterList(f, "autotest", "198.51.100.1=", "203.0.113.9=", "203.0.113.10="),
+			},
+		},
+	})
+}
+
+func TestMikrotikResourceIpFirewallAddressListSet_timeoutChange(t *testing.T) {
+	f := newFakeRouter()
+	provider := testFakeProvider(newFakeApiServer(t, f))
+	entryId := func(address string) string {
+		for _, item := range f.items("/ip/firewall/address-list") {
+			if item["address"] == address {
+				return item[".id"]
+			}
+		}
+		return ""
+	}
+	var id string
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testFakeRouterEmpty(f, "/ip/firewall/address-list"),
+		Steps: []resource.TestStep{
+			{
+				Config: provider + testAccIpFirewallAddressListSet(`
+	entry {
+		address = "198.51.100.1"
+		timeout = "1h"
+	}
+`),
+				Check: func(s *terraform.State) error {
+					id = entryId("198.51.100.1")
+					return testFakeRouterLastCommand(f, "/ip/firewall/address-list/add", "=address=198.51.100.1", "=list=autotest", "=timeout=1h")(s)
+				},
+			},
+			{
+				Config: provider + testAccIpFirewallAddressListSet(`
+	entry {
+		address = "198.51.100.1"
+		timeout = "2h"
+	}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testFakeRouterLastCommand(f, "/ip/firewall/address-list/add", "=address=198.51.100.1", "=list=autotest", "=timeout=2h"),
+					testFakeRouterList(f, "autotest", "198.51.100.1="),
+					func(s *terraform.State) error {
+						if entryId("198.51.100.1") == id {
+							return fmt.Errorf("The entry %s should have been replaced for its new timeout", id)
+						}
+						id = entryId("198.51.100.1")
+						return nil
+					},
+				),
+			},
+			{
+				// dropping the timeout makes the entry permanent
+				Config: provider + testAccIpFirewallAddressListSet(`
+	entry {
+		address = "198.51.100.1"
+	}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testFakeRouterLastCommand(f, "/ip/firewall/address-list/add", "=address=198.51.100.1", "=list=autotest"),
+					func(s *terraform.State) error {
+						if entryId("198.51.100.1") == id {
+							return fmt.Errorf("The entry %s should have been replaced without a timeout", id)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
@@ -3,6 +3,7 @@ package mikrotik
 import (
 	"fmt"
 	"reflect"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -181,7 +182,7 @@ func testAccCheckMikrotikIpFirewallAddressListDestroy(s *terraform.State) error
 }
 
 func TestAccMikrotikResourceIpFirewallAddressList_add_delete(t *testing.T) {
-	c := NewClient(GetConfigFromEnv())
+	c := testAccClient(t)
 
 	address := "192.168.1.0/24"
 	list := "list1"
@@ -199,6 +200,7 @@ func TestAccMikrotikResourceIpFirewallAddressList_add_delete(t *testing.T) {
 		list,
 		comment,
 		disabled,
+		"",
 	)
 
 	if err != nil {
@@ -243,7 +245,7 @@ func TestAccMikrotikResourceIpFirewallAddressList_add_delete(t *testing.T) {
 }
 
 func TestAccMikrotikResourceIpFirewallAddressList_add_update_delete(t *testing.T) {
-	c := NewClient(GetConfigFromEnv())
+	c := testAccClient(t)
 
 	initial_address := "192.168.1.0/24"
 	updated_address := "192.168.2.0/24"
@@ -266,6 +268,7 @@ func TestAccMikrotikResourceIpFirewallAddressList_add_update_delete(t *testing.T
 		initial_list,
 		initial_comment,
 		initial_disabled,
+		"",
 	)
 
 	if err != nil {
@@ -276,7 +279,7 @@ func TestAccMikrotikResourceIpFirewallAddressList_add_update_delete(t *testing.T
 		t.Errorf("The created ip firewall address-list does not have an Id: %v", init_fwlist)
 	}
 
-	updated_fwlist, err := c.UpdateIpFirewallAddressList(init_fwlist.Id, updated_address, updated_list, updated_comment, updated_disabled)
+	updated_fwlist, err := c.UpdateIpFirewallAddressList(init_fwlist.Id, updated_address, updated_list, updated_comment, updated_disabled, "")
 
 	if err != nil {
 		t.Errorf("Error updating the ip firewall address-list with: %v", err)
@@ -316,13 +319,180 @@ func TestAccMikrotikResourceIpFirewallAddressList_add_update_delete(t *testing.T
 }
 
 func TestAccMikrotikResourceIpFirewallAddressList_find_nonexisting(t *testing.T) {
-	c := NewClient(GetConfigFromEnv())
+	c := testAccClient(t)
 
 	fwlistId := "Invalid id"
 	_, err := c.FindIpFirewallAddressList(fwlistId)
 
-	expectedErrStr := fmt.Sprintf("ip firewall address-list `%s`not found", fwlistId)
+	expectedErrStr := fmt.Sprintf("ip firewall address-list `%s` not found", fwlistId)
 	if err == nil || err.Error() != expectedErrStr {
 		t.Errorf("client should have received error indicating the following ip firewall address-list `%s`was not found. Instead error was nil", fwlistId)
 	}
 }
+
+func TestMikrotikResourceIpFirewallAddressList_lifecycle(t *testing.T) {
+	f := newFakeRouter()
+	provider := testFakeProvider(newFakeApiServer(t, f))
+	resourceName := "mikrotik_ip_firewall_address_list.autotest"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testFakeRouterEmpty(f, "/ip/firewall/address-list"),
+		Steps: []resource.TestStep{
+			{
+				Config: provider + testAccIpFirewallAddressList(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testFakeRouterItem(f, "/ip/firewall/address-list", resourceName, map[string]string{
+						"address": originalAddress,
+						"list":    originalList,
+						"comment": originalComment,
+					}),
+					resource.TestCheckResourceAttr(resourceName, "list", originalList),
+				),
+			},
+			{
+				Config: provider + testAccIpFirewallAddressListUpdateAddress(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testFakeRouterItem(f, "/ip/firewall/address-list", resourceName, map[string]string{
+						"address": updatedAddress,
+					}),
+					testFakeRouterLastCommand(f, "/ip/firewall/address-list/set", "=address="+updatedAddress),
+				),
+			},
+			{
+				Config: provider + testAccIpFirewallAddressListUpdateList(),
+				Check: testFakeRouterItem(f, "/ip/firewall/address-list", resourceName, map[string]string{
+					"list": updatedList,
+				}),
+			},
+			{
+				Config: provider + testAccIpFirewallAddressListUpdateComment(),
+				Check: testFakeRouterItem(f, "/ip/firewall/address-list", resourceName, map[string]string{
+					"comment": updatedComment,
+				}),
+			},
+			{
+				Config:            provider + testAccIpFirewallAddressListUpdateComment(),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: provider + fmt.Sprintf(`
+resource "mikrotik_ip_firewall_address_list" "autotest" {
+	address = "%s"
+	list = "%s"
+}
+`, updatedAddress, updatedList),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testFakeRouterItem(f, "/ip/firewall/address-list", resourceName, map[string]string{
+						"comment": "",
+					}),
+					resource.TestCheckResourceAttr(resourceName, "comment", ""),
+				),
+			},
+		},
+	})
+}
+
+func TestMikrotikProvider_SuppressTimeoutCountdown(t *testing.T) {
+	tests := []struct {
+		old, new string
+		suppress bool
+	}{
+		{"23h59m50s", "1d", true},
+		{"1d", "1d", true},
+		{"1d00:00:00", "1d", true},
+		{"59m", "1d", true},
+		{"1d1s", "1d", false},
+		{"0s", "1d", false},
+		{"", "1d", false},
+		{"23h", "", false},
+		{"later", "1d", false},
+		{"23h", "soon", false},
+	}
+
+	for _, test := range tests {
+		if actual := suppressTimeoutCountdown("timeout", test.old, test.new, nil); actual != test.suppress {
+			t.Errorf("suppressTimeoutCountdown(%q, %q) returned %v, expected %v", test.old, test.new, actual, test.suppress)
+		}
+	}
+}
+
+func testAccIpFirewallAddressListTimeout(timeout string) string {
+	return fmt.Sprintf(`
+resource "mikrotik_ip_firewall_address_list" "autotest" {
+	address = "%s"
+	list = "%s"
+	%s
+}
+`, originalAddress, originalList, timeout)
+}
+
+func TestMikrotikResourceIpFirewallAddressList_timeout(t *testing.T) {
+	f := newFakeRouter()
+	provider := testFakeProvider(newFakeApiServer(t, f))
+	resourceName := "mikrotik_ip_firewall_address_list.autotest"
+
+	// the router counts the timeout down and reports the entry as dynamic
+	countdown := func() {
+		for _, item := range f.tables["/ip/firewall/address-list"] {
+			item["timeout"] = "23h59m50s"
+			item["dynamic"] = "true"
+			item["creation-time"] = "2026-10-18 09:00:00"
+		}
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testFakeRouterEmpty(f, "/ip/firewall/address-list"),
+		Steps: []resource.TestStep{
+			{
+				Config: provider + testAccIpFirewallAddressListTimeout(`timeout = "1d"`),
+				Check: testFakeRouterItem(f, "/ip/firewall/address-list", resourceName, map[string]string{
+					"timeout": "1d",
+				}),
+			},
+			{
+				PreConfig: countdown,
+				Config:    provider + testAccIpFirewallAddressListTimeout(`timeout = "1d"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "timeout", "23h59m50s"),
+					resource.TestCheckResourceAttr(resourceName, "dynamic", "true"),
+					resource.TestCheckResourceAttr(resourceName, "creation_time", "2026-10-18 09:00:00"),
+					func(s *terraform.State) error {
+						if cmd := f.lastCommand("/ip/firewall/address-list/set"); cmd != nil {
+							return fmt.Errorf("The time left should not be reset, the router received %v", cmd)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: provider + testAccIpFirewallAddressListTimeout(`timeout = "1h"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testFakeRouterItem(f, "/ip/firewall/address-list", resourceName, map[string]string{
+						"timeout": "1h",
+					}),
+					testFakeRouterLastCommand(f, "/ip/firewall/address-list/set", "=timeout=1h"),
+				),
+			},
+		},
+	})
+}
+
+func TestMikrotikResourceIpFirewallAddressList_invalidTimeout(t *testing.T) {
+	f := newFakeRouter()
+	provider := testFakeProvider(newFakeApiServer(t, f))
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testFakeRouterEmpty(f, "/ip/firewall/address-list"),
+		Steps: []resource.TestStep{
+			{
+				Config:      provider + testAccIpFirewallAddressListTimeout(`timeout = "1y"`),
+				ExpectError: regexp.MustCompile("expected timeout to be a duration"),
+			},
+		},
+	})
+}
@@ -0,0 +1,9 @@
+package mikrotik
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func resourceIpFirewallFilterRules() *schema.Resource {
+	return ipFirewallFilterMenu.rulesResource()
+}
@@ -0,0 +1,159 @@
+package mikrotik
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func testAccIpFirewallFilterRules(comments ...string) string {
+	var rules []string
+	for _, comment := range comments {
+		chain := strings.SplitN(comment, "-", 2)[0]
+		rules = append(rules, fmt.Sprintf(`
+	rule {
+		chain = "%s"
+		action = "accept"
+		comment = "%s"
+	}`, chain, comment))
+	}
+
+	return fmt.Sprintf(`
+resource "mikrotik_ip_firewall_filter_rules" "autotest" {
+	chains = ["input", "forward"]
+%s
+}
+`, strings.Join(rules, "\n"))
+}
+
+func TestMikrotikResourceIpFirewallFilterRules_lifecycle(t *testing.T) {
+	f := newFakeRouter()
+	f.tables["/ip/firewall/filter"] = []map[string]string{
+		{".id": "*F0", "chain": "output", "action": "accept", "comment": "output-keep"},
+		{".id": "*F1", "chain": "input", "action": "passthrough", "comment": "dynamic", "dynamic": "true"},
+	}
+	provider := testFakeProvider(newFakeApiServer(t, f))
+	resourceName := "mikrotik_ip_firewall_filter_rules.autotest"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: testAccProviders,
+		CheckDestroy: func(s *terraform.State) error {
+			return testFakeRouterOrder(f, "/ip/firewall/filter", "output-keep", "dynamic")(s)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: provider + testAccIpFirewallFilterRules("input-ssh", "forward-web", "input-dns"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testFakeRouterOrder(f, "/ip/firewall/filter", "output-keep", "dynamic", "input-ssh", "forward-web", "input-dns"),
+					resource.TestCheckResourceAttr(resourceName, "id", "forward,input"),
+					resource.TestCheckResourceAttr(resourceName, "rule.#", "3"),
+					resource.TestCheckResourceAttr(resourceName, "rule.2.comment", "input-dns"),
+				),
+			},
+			{
+				// reordering moves the existing rules instead of recreating them
+				Config: provider + testAccIpFirewallFilterRules("input-dns", "input-ssh", "forward-web"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testFakeRouterOrder(f, "/ip/firewall/filter", "output-keep", "dynamic", "input-dns", "input-ssh", "forward-web"),
+					testFakeRouterLastCommand(f, "/ip/firewall/filter/move", "=numbers=*3", "=destination=*1"),
+					testFakeRouterLastCommand(f, "/ip/firewall/filter/add", "=action=accept", "=chain=input", "=comment=input-dns", "=disabled=no"),
+				),
+			},
+			{
+				Config: provider + testAccIpFirewallFilterRules("input-dns", "forward-vpn", "forward-web"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testFakeRouterOrder(f, "/ip/firewall/filter", "output-keep", "dynamic", "input-dns", "forward-vpn", "forward-web"),
+					resource.TestCheckResourceAttr(resourceName, "rule.1.comment", "forward-vpn"),
+				),
+			},
+			{
+				// a rule moved on the router is detected and moved back
+				PreConfig: func() {
+					f.run([]string{"/ip/firewall/filter/move", "=numbers=*2", "=destination=*3"})
+				},
+				Config: provider + testAccIpFirewallFilterRules("input-dns", "forward-vpn", "forward-web"),
+				Check:  testFakeRouterOrder(f, "/ip/firewall/filter", "output-keep", "dynamic", "input-dns", "forward-vpn", "forward-web"),
+			},
+			{
+				Config:            provider + testAccIpFirewallFilterRules("input-dns", "forward-vpn", "forward-web"),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     "forward,input",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestMikrotikResourceIpFirewallFilterRules_foreignChain(t *testing.T) {
+	f := newFakeRouter()
+	provider := testFakeProvider(newFakeApiServer(t, f))
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testFakeRouterEmpty(f, "/ip/firewall/filter"),
+		Steps: []resource.TestStep{
+			{
+				Config:      provider + testAccIpFirewallFilterRules("output-ssh"),
+				ExpectError: regexp.MustCompile("rule 0 is in chain `output`"),
+			},
+		},
+	})
+}
+
+func TestMikrotikResourceIpFirewallFilterRules_normalizedValues(t *testing.T) {
+	f := newFakeRouter()
+	provider := testFakeProvider(newFakeApiServer(t, f))
+	config := provider + `
+resource "mikrotik_ip_firewall_filter_rules" "autotest" {
+	chains = ["input"]
+
+	rule {
+		chain = "input"
+		src_address = "10.0.0.1/32"
+		connection_state = "related,established"
+		comment = "input-admin"
+	}
+}
+`
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testFakeRouterEmpty(f, "/ip/firewall/filter"),
+		Steps: []resource.TestStep{
+			{
+				// the router prints 10.0.0.1, established,related and the
+				// accept action, none of which may plan a change
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					func(s *terraform.State) error {
+						items := f.items("/ip/firewall/filter")
+						if len(items) != 1 || items[0]["src-address"] != "10.0.0.1" || items[0]["connection-state"] != "established,related" || items[0]["action"] != "accept" {
+							return fmt.Errorf("Unexpected rules on the router %v", items)
+						}
+						return nil
+					},
+					resource.TestCheckResourceAttr("mikrotik_ip_firewall_filter_rules.autotest", "rule.0.src_address", "10.0.0.1/32"),
+				),
+			},
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+			{
+				// an apply keeps the rule rather than adding it again
+				Config: config,
+				Check: func(s *terraform.State) error {
+					if items := f.items("/ip/firewall/filter"); len(items) != 1 || items[0][".id"] != "*1" {
+						return fmt.Errorf("The rule was replaced, the router has %v", items)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
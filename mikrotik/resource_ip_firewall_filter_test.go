@@ -210,3 +210,140 @@ func testAccCheckMikrotikIpFirewallFilterDestroy(s *terraform.State) error {
 	}
 	return nil
 }
+
+func TestMikrotikResourceIpFirewallFilter_lifecycle(t *testing.T) {
+	f := newFakeRouter()
+	provider := testFakeProvider(newFakeApiServer(t, f))
+	resourceName := "mikrotik_ip_firewall_filter.autotest"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testFakeRouterEmpty(f, "/ip/firewall/filter"),
+		Steps: []resource.TestStep{
+			{
+				Config: provider + testAccIpFirewallFilter(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testFakeRouterItem(f, "/ip/firewall/filter", resourceName, map[string]string{
+						"action":      origAction,
+						"chain":       origChain,
+						"src-address": origSrcAddr,
+						"dst-address": origDstAddr,
+					}),
+					resource.TestCheckResourceAttr(resourceName, "chain", origChain),
+				),
+			},
+			{
+				Config: provider + testAccIpFirewallFilterUpdateAction(),
+				Check: testFakeRouterItem(f, "/ip/firewall/filter", resourceName, map[string]string{
+					"action": updatedAction,
+				}),
+			},
+			{
+				Config: provider + testAccIpFirewallFilterUpdateChain(),
+				Check: testFakeRouterItem(f, "/ip/firewall/filter", resourceName, map[string]string{
+					"chain": updatedChain,
+				}),
+			},
+			{
+				Config: provider + testAccIpFirewallFilterUpdateSrcAddress(),
+				Check: testFakeRouterItem(f, "/ip/firewall/filter", resourceName, map[string]string{
+					"src-address": updatedSrcAddr,
+				}),
+			},
+			{
+				Config: provider + testAccIpFirewallFilterUpdateDstAddress(),
+				Check: testFakeRouterItem(f, "/ip/firewall/filter", resourceName, map[string]string{
+					"dst-address": updatedDstAddr,
+				}),
+			},
+			{
+				Config:            provider + testAccIpFirewallFilterUpdateDstAddress(),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestMikrotikResourceIpFirewallFilter_clearAttributes(t *testing.T) {
+	f := newFakeRouter()
+	provider := testFakeProvider(newFakeApiServer(t, f))
+	resourceName := "mikrotik_ip_firewall_filter.autotest"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testFakeRouterEmpty(f, "/ip/firewall/filter"),
+		Steps: []resource.TestStep{
+			{
+				Config: provider + `
+resource "mikrotik_ip_firewall_filter" "autotest" {
+	action = "accept"
+	chain = "input"
+	protocol = "tcp"
+	dst_port = "22"
+	comment = "ssh"
+	log = true
+}
+`,
+				Check: testFakeRouterItem(f, "/ip/firewall/filter", resourceName, map[string]string{
+					"dst-port": "22",
+					"comment":  "ssh",
+					"log":      "yes",
+					"fragment": "",
+				}),
+			},
+			{
+				Config: provider + `
+resource "mikrotik_ip_firewall_filter" "autotest" {
+	action = "accept"
+	chain = "input"
+	protocol = "tcp"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testFakeRouterItem(f, "/ip/firewall/filter", resourceName, map[string]string{
+						"action":   "accept",
+						"protocol": "tcp",
+						"dst-port": "",
+						"comment":  "",
+						"log":      "no",
+					}),
+					testFakeRouterLastCommand(f, "/ip/firewall/filter/set", "=log=no"),
+					testFakeRouterUnset(f, "/ip/firewall/filter", "comment", "dst-port"),
+					resource.TestCheckResourceAttr(resourceName, "dst_port", ""),
+				),
+			},
+		},
+	})
+}
+
+func TestMikrotikResourceIpFirewallFilter_reorderedConnectionState(t *testing.T) {
+	f := newFakeRouter()
+	provider := testFakeProvider(newFakeApiServer(t, f))
+	resourceName := "mikrotik_ip_firewall_filter.autotest"
+	config := provider + `
+resource "mikrotik_ip_firewall_filter" "autotest" {
+	chain = "input"
+	connection_state = "related,established"
+}
+`
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testFakeRouterEmpty(f, "/ip/firewall/filter"),
+		Steps: []resource.TestStep{
+			{
+				// the router prints established,related
+				Config: config,
+				Check: testFakeRouterItem(f, "/ip/firewall/filter", resourceName, map[string]string{
+					"connection-state": "established,related",
+				}),
+			},
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+		},
+	})
+}
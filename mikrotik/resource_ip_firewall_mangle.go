@@ -0,0 +1,67 @@
+package mikrotik
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+var ipFirewallMangleMenu = &mikrotikMenu{
+	path: "/ip/firewall/mangle",
+	name: "ip firewall mangle",
+	item: IpFirewallMangle{},
+}
+
+// ipFirewallMangleActions are the actions of the mangle table. The mark-*
+// and change-* actions take the matching new_* attribute.
+var ipFirewallMangleActions = []string{
+	"accept",
+	"add-dst-to-address-list",
+	"add-src-to-address-list",
+	"change-dscp",
+	"change-mss",
+	"change-ttl",
+	"clear-df",
+	"fasttrack-connection",
+	"jump",
+	"log",
+	"mark-connection",
+	"mark-packet",
+	"mark-routing",
+	"passthrough",
+	"return",
+	"route",
+	"set-priority",
+	"strip-ipv4-options",
+}
+
+func resourceIpFirewallMangle() *schema.Resource {
+	r := ipFirewallMangleMenu.resource()
+	r.Schema["action"].ValidateFunc = validation.StringInSlice(ipFirewallMangleActions, false)
+	return r
+}
+
+type IpFirewallMangle struct {
+	Id                   string `mikrotik:".id"`
+	Action               string `mikrotik:"action,default=accept"`
+	Address_list         string `mikrotik:"address-list"`
+	Address_list_timeout string `mikrotik:"address-list-timeout"`
+	Chain                string `mikrotik:"chain,required"`
+	Comment              string `mikrotik:"comment"`
+	Copy_from            string `mikrotik:"copy-from,writeonly"`
+	Disabled             bool   `mikrotik:"disabled,default=false"`
+	Jump_target          string `mikrotik:"jump-target"`
+	Log                  *bool  `mikrotik:"log"`
+	Log_prefix           string `mikrotik:"log-prefix"`
+	New_connection_mark  string `mikrotik:"new-connection-mark"`
+	New_dscp             string `mikrotik:"new-dscp"`
+	New_mss              string `mikrotik:"new-mss"`
+	New_packet_mark      string `mikrotik:"new-packet-mark"`
+	New_priority         string `mikrotik:"new-priority"`
+	New_routing_mark     string `mikrotik:"new-routing-mark"`
+	New_ttl              string `mikrotik:"new-ttl"`
+	Passthrough          bool   `mikrotik:"passthrough,default=true"`
+	Place_before         string `mikrotik:"place-before,writeonly,move"`
+	Route_dst            string `mikrotik:"route-dst"`
+	Dynamic              bool   `mikrotik:"dynamic,readonly"`
+	IpFirewallMatchers
+}
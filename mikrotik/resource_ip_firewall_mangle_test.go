@@ -0,0 +1,128 @@
+package mikrotik
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccMikrotikResourceIpFirewallMangle_create(t *testing.T) {
+	resourceName := "mikrotik_ip_firewall_mangle.autotest"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckMenuItemDestroy(ipFirewallMangleMenu, "mikrotik_ip_firewall_mangle"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIpFirewallMangle(`
+	action = "mark-connection"
+	new_connection_mark = "isp2"
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMenuItemExists(ipFirewallMangleMenu, resourceName),
+					resource.TestCheckResourceAttr(resourceName, "action", "mark-connection"),
+					resource.TestCheckResourceAttr(resourceName, "new_connection_mark", "isp2"),
+					resource.TestCheckResourceAttr(resourceName, "passthrough", "true"),
+				),
+			},
+			{
+				Config: testAccIpFirewallMangle(`
+	action = "mark-routing"
+	new_routing_mark = "isp2"
+	passthrough = false
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMenuItemExists(ipFirewallMangleMenu, resourceName),
+					resource.TestCheckResourceAttr(resourceName, "action", "mark-routing"),
+					resource.TestCheckResourceAttr(resourceName, "new_routing_mark", "isp2"),
+					resource.TestCheckResourceAttr(resourceName, "passthrough", "false"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccIpFirewallMangle(action string) string {
+	return fmt.Sprintf(`
+resource "mikrotik_ip_firewall_mangle" "autotest" {
+	chain = "prerouting"
+	in_interface = "ether2"
+	comment = "autotest"
+	%s
+}
+`, action)
+}
+
+func TestMikrotikResourceIpFirewallMangle_lifecycle(t *testing.T) {
+	f := newFakeRouter()
+	provider := testFakeProvider(newFakeApiServer(t, f))
+	resourceName := "mikrotik_ip_firewall_mangle.autotest"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testFakeRouterEmpty(f, "/ip/firewall/mangle"),
+		Steps: []resource.TestStep{
+			{
+				Config: provider + testAccIpFirewallMangle(`
+	action = "mark-connection"
+	new_connection_mark = "isp2"
+`),
+				Check: testFakeRouterItem(f, "/ip/firewall/mangle", resourceName, map[string]string{
+					"chain":               "prerouting",
+					"action":              "mark-connection",
+					"new-connection-mark": "isp2",
+					"passthrough":         "yes",
+				}),
+			},
+			{
+				Config: provider + testAccIpFirewallMangle(`
+	action = "mark-routing"
+	new_routing_mark = "isp2"
+	passthrough = false
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testFakeRouterItem(f, "/ip/firewall/mangle", resourceName, map[string]string{
+						"action":              "mark-routing",
+						"new-connection-mark": "",
+						"new-routing-mark":    "isp2",
+						"passthrough":         "no",
+					}),
+					testFakeRouterLastCommand(f, "/ip/firewall/mangle/set", "=action=mark-routing", "=new-routing-mark=isp2", "=passthrough=no"),
+					testFakeRouterUnset(f, "/ip/firewall/mangle", "new-connection-mark"),
+				),
+			},
+			{
+				Config: provider + testAccIpFirewallMangle(`
+	action = "change-mss"
+	new_mss = "clamp-to-pmtu"
+	protocol = "tcp"
+	tcp_flags = "syn"
+`),
+				Check: testFakeRouterItem(f, "/ip/firewall/mangle", resourceName, map[string]string{
+					"action":           "change-mss",
+					"new-mss":          "clamp-to-pmtu",
+					"new-routing-mark": "",
+					"passthrough":      "yes",
+					"tcp-flags":        "syn",
+				}),
+			},
+			{
+				Config: provider + testAccIpFirewallMangle(`
+	action = "change-mss"
+	new_mss = "clamp-to-pmtu"
+	protocol = "tcp"
+	tcp_flags = "syn"
+`),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
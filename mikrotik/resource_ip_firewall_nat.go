@@ -0,0 +1,55 @@
+package mikrotik
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+var ipFirewallNatMenu = &mikrotikMenu{
+	path: "/ip/firewall/nat",
+	name: "ip firewall nat",
+	item: IpFirewallNat{},
+}
+
+// ipFirewallNatActions are the actions of the nat table. to_addresses and
+// to_ports only apply to the address translating ones.
+var ipFirewallNatActions = []string{
+	"accept",
+	"add-dst-to-address-list",
+	"add-src-to-address-list",
+	"dst-nat",
+	"jump",
+	"log",
+	"masquerade",
+	"netmap",
+	"passthrough",
+	"redirect",
+	"return",
+	"same",
+	"src-nat",
+}
+
+func resourceIpFirewallNat() *schema.Resource {
+	r := ipFirewallNatMenu.resource()
+	r.Schema["action"].ValidateFunc = validation.StringInSlice(ipFirewallNatActions, false)
+	return r
+}
+
+type IpFirewallNat struct {
+	Id                   string `mikrotik:".id"`
+	Action               string `mikrotik:"action,default=accept"`
+	Address_list         string `mikrotik:"address-list"`
+	Address_list_timeout string `mikrotik:"address-list-timeout"`
+	Chain                string `mikrotik:"chain,required"`
+	Comment              string `mikrotik:"comment"`
+	Copy_from            string `mikrotik:"copy-from,writeonly"`
+	Disabled             bool   `mikrotik:"disabled,default=false"`
+	Jump_target          string `mikrotik:"jump-target"`
+	Log                  *bool  `mikrotik:"log"`
+	Log_prefix           string `mikrotik:"log-prefix"`
+	Place_before         string `mikrotik:"place-before,writeonly,move"`
+	To_addresses         string `mikrotik:"to-addresses"`
+	To_ports             string `mikrotik:"to-ports"`
+	Dynamic              bool   `mikrotik:"dynamic,readonly"`
+	IpFirewallMatchers
+}
@@ -0,0 +1,181 @@
+package mikrotik
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccMikrotikResourceIpFirewallNat_create(t *testing.T) {
+	resourceName := "mikrotik_ip_firewall_nat.autotest"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckMenuItemDestroy(ipFirewallNatMenu, "mikrotik_ip_firewall_nat"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIpFirewallNat("masquerade", ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMenuItemExists(ipFirewallNatMenu, resourceName),
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttr(resourceName, "action", "masquerade"),
+					resource.TestCheckResourceAttr(resourceName, "chain", "srcnat"),
+				),
+			},
+			{
+				Config: testAccIpFirewallNat("src-nat", `to_addresses = "198.51.100.1"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMenuItemExists(ipFirewallNatMenu, resourceName),
+					resource.TestCheckResourceAttr(resourceName, "action", "src-nat"),
+					resource.TestCheckResourceAttr(resourceName, "to_addresses", "198.51.100.1"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccIpFirewallNat(action, extra string) string {
+	return fmt.Sprintf(`
+resource "mikrotik_ip_firewall_nat" "autotest" {
+	chain = "srcnat"
+	action = "%s"
+	out_interface = "ether1"
+	comment = "autotest"
+	%s
+}
+`, action, extra)
+}
+
+func TestMikrotikResourceIpFirewallNat_lifecycle(t *testing.T) {
+	f := newFakeRouter()
+	provider := testFakeProvider(newFakeApiServer(t, f))
+	resourceName := "mikrotik_ip_firewall_nat.autotest"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testFakeRouterEmpty(f, "/ip/firewall/nat"),
+		Steps: []resource.TestStep{
+			{
+				Config: provider + testAccIpFirewallNat("masquerade", ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testFakeRouterItem(f, "/ip/firewall/nat", resourceName, map[string]string{
+						"chain":         "srcnat",
+						"action":        "masquerade",
+						"out-interface": "ether1",
+						"to-addresses":  "",
+					}),
+					resource.TestCheckResourceAttr(resourceName, "to_addresses", ""),
+				),
+			},
+			{
+				Config: provider + testAccIpFirewallNat("dst-nat", `
+	to_addresses = "192.168.88.10"
+	to_ports = "8080"
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testFakeRouterItem(f, "/ip/firewall/nat", resourceName, map[string]string{
+						"action":       "dst-nat",
+						"to-addresses": "192.168.88.10",
+						"to-ports":     "8080",
+					}),
+					testFakeRouterLastCommand(f, "/ip/firewall/nat/set", "=action=dst-nat", "=to-addresses=192.168.88.10", "=to-ports=8080"),
+				),
+			},
+			{
+				Config: provider + testAccIpFirewallNat("netmap", `to_addresses = "10.0.0.0/24"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testFakeRouterItem(f, "/ip/firewall/nat", resourceName, map[string]string{
+						"action":       "netmap",
+						"to-addresses": "10.0.0.0/24",
+						"to-ports":     "",
+					}),
+					testFakeRouterLastCommand(f, "/ip/firewall/nat/set", "=action=netmap", "=to-addresses=10.0.0.0/24"),
+					testFakeRouterUnset(f, "/ip/firewall/nat", "to-ports"),
+				),
+			},
+			{
+				Config:            provider + testAccIpFirewallNat("netmap", `to_addresses = "10.0.0.0/24"`),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestMikrotikResourceIpFirewallNat_invalidAction(t *testing.T) {
+	f := newFakeRouter()
+	provider := testFakeProvider(newFakeApiServer(t, f))
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testFakeRouterEmpty(f, "/ip/firewall/nat"),
+		Steps: []resource.TestStep{
+			{
+				Config:      provider + testAccIpFirewallNat("snat", ""),
+				ExpectError: regexp.MustCompile(`expected action to be one of`),
+			},
+		},
+	})
+}
+
+func testAccIpFirewallNatOrdered(thirdPlaceBefore string) string {
+	return fmt.Sprintf(`
+resource "mikrotik_ip_firewall_nat" "first" {
+	chain = "dstnat"
+	action = "redirect"
+	to_ports = "53"
+	comment = "first"
+}
+
+resource "mikrotik_ip_firewall_nat" "second" {
+	chain = "dstnat"
+	action = "accept"
+	comment = "second"
+	place_before = mikrotik_ip_firewall_nat.first.id
+}
+
+resource "mikrotik_ip_firewall_nat" "third" {
+	chain = "dstnat"
+	action = "accept"
+	comment = "third"
+	%s
+
+	depends_on = [mikrotik_ip_firewall_nat.second]
+}
+`, thirdPlaceBefore)
+}
+
+func TestMikrotikResourceIpFirewallNat_placeBefore(t *testing.T) {
+	f := newFakeRouter()
+	provider := testFakeProvider(newFakeApiServer(t, f))
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testFakeRouterEmpty(f, "/ip/firewall/nat"),
+		Steps: []resource.TestStep{
+			{
+				Config: provider + testAccIpFirewallNatOrdered(""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testFakeRouterOrder(f, "/ip/firewall/nat", "second", "first", "third"),
+					resource.TestCheckResourceAttrPair("mikrotik_ip_firewall_nat.second", "place_before", "mikrotik_ip_firewall_nat.first", "id"),
+				),
+			},
+			{
+				// changing place_before moves the existing rule
+				Config: provider + testAccIpFirewallNatOrdered("place_before = mikrotik_ip_firewall_nat.first.id"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testFakeRouterOrder(f, "/ip/firewall/nat", "second", "third", "first"),
+					testFakeRouterLastCommand(f, "/ip/firewall/nat/move", "=numbers=*3", "=destination=*1"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,51 @@
+package mikrotik
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+var ipFirewallRawMenu = &mikrotikMenu{
+	path: "/ip/firewall/raw",
+	name: "ip firewall raw",
+	item: IpFirewallRaw{},
+}
+
+// ipFirewallRawActions are the actions of the raw table, which sees packets
+// before connection tracking.
+var ipFirewallRawActions = []string{
+	"accept",
+	"add-dst-to-address-list",
+	"add-src-to-address-list",
+	"drop",
+	"jump",
+	"log",
+	"notrack",
+	"passthrough",
+	"return",
+}
+
+func resourceIpFirewallRaw() *schema.Resource {
+	r := ipFirewallRawMenu.resource()
+	r.Schema["action"].ValidateFunc = validation.StringInSlice(ipFirewallRawActions, false)
+	return r
+}
+
+// IpFirewallRaw is a rule of the raw table. It only takes the matchers which
+// do not need connection tracking, see IpFirewallPacketMatchers.
+type IpFirewallRaw struct {
+	Id                   string `mikrotik:".id"`
+	Action               string `mikrotik:"action,default=accept"`
+	Address_list         string `mikrotik:"address-list"`
+	Address_list_timeout string `mikrotik:"address-list-timeout"`
+	Chain                string `mikrotik:"chain,required"`
+	Comment              string `mikrotik:"comment"`
+	Copy_from            string `mikrotik:"copy-from,writeonly"`
+	Disabled             bool   `mikrotik:"disabled,default=false"`
+	Jump_target          string `mikrotik:"jump-target"`
+	Log                  *bool  `mikrotik:"log"`
+	Log_prefix           string `mikrotik:"log-prefix"`
+	Place_before         string `mikrotik:"place-before,writeonly,move"`
+	Dynamic              bool   `mikrotik:"dynamic,readonly"`
+	IpFirewallPacketMatchers
+}
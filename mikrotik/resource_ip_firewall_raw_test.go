@@ -0,0 +1,112 @@
+package mikrotik
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccMikrotikResourceIpFirewallRaw_create(t *testing.T) {
+	resourceName := "mikrotik_ip_firewall_raw.autotest"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckMenuItemDestroy(ipFirewallRawMenu, "mikrotik_ip_firewall_raw"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIpFirewallRaw("notrack", "203.0.113.0/24"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMenuItemExists(ipFirewallRawMenu, resourceName),
+					resource.TestCheckResourceAttr(resourceName, "action", "notrack"),
+					resource.TestCheckResourceAttr(resourceName, "chain", "prerouting"),
+					resource.TestCheckResourceAttr(resourceName, "dst_address", "203.0.113.0/24"),
+				),
+			},
+			{
+				Config: testAccIpFirewallRaw("drop", "203.0.113.0/24"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMenuItemExists(ipFirewallRawMenu, resourceName),
+					resource.TestCheckResourceAttr(resourceName, "action", "drop"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccIpFirewallRaw(action, dstAddress string) string {
+	return fmt.Sprintf(`
+resource "mikrotik_ip_firewall_raw" "autotest" {
+	chain = "prerouting"
+	action = "%s"
+	protocol = "udp"
+	dst_address = "%s"
+	comment = "autotest"
+}
+`, action, dstAddress)
+}
+
+func TestMikrotikResourceIpFirewallRaw_lifecycle(t *testing.T) {
+	f := newFakeRouter()
+	provider := testFakeProvider(newFakeApiServer(t, f))
+	resourceName := "mikrotik_ip_firewall_raw.autotest"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testFakeRouterEmpty(f, "/ip/firewall/raw"),
+		Steps: []resource.TestStep{
+			{
+				Config: provider + testAccIpFirewallRaw("notrack", "203.0.113.0/24"),
+				Check: testFakeRouterItem(f, "/ip/firewall/raw", resourceName, map[string]string{
+					"chain":       "prerouting",
+					"action":      "notrack",
+					"protocol":    "udp",
+					"dst-address": "203.0.113.0/24",
+				}),
+			},
+			{
+				Config: provider + testAccIpFirewallRaw("drop", "198.51.100.0/24"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testFakeRouterItem(f, "/ip/firewall/raw", resourceName, map[string]string{
+						"action":      "drop",
+						"dst-address": "198.51.100.0/24",
+					}),
+					testFakeRouterLastCommand(f, "/ip/firewall/raw/set", "=action=drop", "=dst-address=198.51.100.0/24"),
+				),
+			},
+			{
+				Config:            provider + testAccIpFirewallRaw("drop", "198.51.100.0/24"),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestMikrotikResourceIpFirewallRaw_connectionMatcher(t *testing.T) {
+	f := newFakeRouter()
+	provider := testFakeProvider(newFakeApiServer(t, f))
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testFakeRouterEmpty(f, "/ip/firewall/raw"),
+		Steps: []resource.TestStep{
+			{
+				Config: provider + `
+resource "mikrotik_ip_firewall_raw" "autotest" {
+	chain = "prerouting"
+	connection_state = "new"
+}
+`,
+				ExpectError: regexp.MustCompile(`An argument named "connection_state" is not expected here`),
+			},
+		},
+	})
+}
@@ -0,0 +1,84 @@
+package mikrotik
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+var ipRouteMenu = &mikrotikMenu{
+	path:   "/ip/route",
+	name:   "ip route",
+	item:   IpRoute{},
+	legacy: ipRouteLegacy,
+}
+
+// ipRouteLegacy translates the blackhole flag of RouterOS 7 to the route
+// type of RouterOS 6, and the routing table to the routing mark, which is
+// absent for the main table. The unreachable and prohibit types of RouterOS 6
+// have no equivalent, so such routes are rejected rather than read as
+// ordinary ones.
+var ipRouteLegacy = &legacyAttributes{
+	send: func(attributes []string) (sent, unset []string) {
+		for _, word := range attributes {
+			switch {
+			case word == "=blackhole=yes":
+				sent = append(sent, "=type=blackhole")
+			case word == "=blackhole=no":
+				sent = append(sent, "=type=unicast")
+			case word == "=routing-table=main":
+				unset = append(unset, "routing-table")
+			case strings.HasPrefix(word, "=routing-table="):
+				sent = append(sent, "=routing-mark="+strings.TrimPrefix(word, "=routing-table="))
+			default:
+				sent = append(sent, word)
+			}
+		}
+		return sent, unset
+	},
+	print: func(attributes map[string]string) error {
+		switch attributes["type"] {
+		case "", "unicast":
+		case "blackhole":
+			attributes["blackhole"] = "true"
+		default:
+			return fmt.Errorf("the ip route `%s` to %s is of type %s, which is not supported: only unicast and blackhole routes are", attributes[".id"], attributes["dst-address"], attributes["type"])
+		}
+		delete(attributes, "type")
+
+		attributes["routing-table"] = "main"
+		if mark := attributes["routing-mark"]; mark != "" {
+			attributes["routing-table"] = mark
+		}
+		delete(attributes, "routing-mark")
+		return nil
+	},
+	names: map[string]string{
+		"routing-table": "routing-mark",
+	},
+}
+
+func resourceIpRoute() *schema.Resource {
+	r := ipRouteMenu.resource()
+	r.Schema["check_gateway"].ValidateFunc = validation.StringInSlice([]string{"arp", "bfd", "bfd-multihop", "none", "ping"}, false)
+	return r
+}
+
+type IpRoute struct {
+	Id            string   `mikrotik:".id"`
+	Dst_address   string   `mikrotik:"dst-address,required"`
+	Gateway       []string `mikrotik:"gateway"`
+	Distance      int      `mikrotik:"distance,computed"`
+	Scope         int      `mikrotik:"scope,computed"`
+	Target_scope  int      `mikrotik:"target-scope,computed"`
+	Routing_table string   `mikrotik:"routing-table,computed"`
+	Pref_src      string   `mikrotik:"pref-src"`
+	Check_gateway string   `mikrotik:"check-gateway"`
+	Blackhole     bool     `mikrotik:"blackhole,default=false"`
+	Comment       string   `mikrotik:"comment"`
+	Disabled      bool     `mikrotik:"disabled,default=false"`
+	Active        bool     `mikrotik:"active,readonly"`
+	Dynamic       bool     `mikrotik:"dynamic,readonly"`
+}
@@ -0,0 +1,216 @@
+package mikrotik
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccMikrotikResourceIpRoute_create(t *testing.T) {
+	resourceName := "mikrotik_ip_route.autotest"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckMenuItemDestroy(ipRouteMenu, "mikrotik_ip_route"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIpRoute(`
+	gateway = ["192.168.88.2"]
+	distance = 5
+	check_gateway = "ping"
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMenuItemExists(ipRouteMenu, resourceName),
+					resource.TestCheckResourceAttr(resourceName, "dst_address", "10.99.0.0/16"),
+					resource.TestCheckResourceAttr(resourceName, "gateway.0", "192.168.88.2"),
+					resource.TestCheckResourceAttr(resourceName, "distance", "5"),
+				),
+			},
+			{
+				Config: testAccIpRoute(`
+	blackhole = true
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMenuItemExists(ipRouteMenu, resourceName),
+					resource.TestCheckResourceAttr(resourceName, "blackhole", "true"),
+					resource.TestCheckResourceAttr(resourceName, "gateway.#", "0"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccIpRoute(attributes string) string {
+	return fmt.Sprintf(`
+resource "mikrotik_ip_route" "autotest" {
+	dst_address = "10.99.0.0/16"
+	comment = "autotest"
+	%s
+}
+`, attributes)
+}
+
+// newFakeRouterVersion returns a fake router reporting the given RouterOS
+// version.
+func newFakeRouterVersion(version string) *fakeRouter {
+	f := newFakeRouter()
+	f.tables["/system/resource"] = []map[string]string{{"version": version}}
+	return f
+}
+
+func TestMikrotikResourceIpRoute_lifecycle(t *testing.T) {
+	f := newFakeRouterVersion("7.12.1 (stable)")
+	provider := testFakeProvider(newFakeApiServer(t, f))
+	resourceName := "mikrotik_ip_route.autotest"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testFakeRouterEmpty(f, "/ip/route"),
+		Steps: []resource.TestStep{
+			{
+				Config: provider + testAccIpRoute(`
+	gateway = ["192.168.88.2", "192.168.88.3"]
+	distance = 5
+	routing_table = "isp2"
+	check_gateway = "ping"
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testFakeRouterItem(f, "/ip/route", resourceName, map[string]string{
+						"dst-address":   "10.99.0.0/16",
+						"gateway":       "192.168.88.2,192.168.88.3",
+						"distance":      "5",
+						"routing-table": "isp2",
+						"check-gateway": "ping",
+						"blackhole":     "no",
+					}),
+					resource.TestCheckResourceAttr(resourceName, "gateway.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "gateway.1", "192.168.88.3"),
+					resource.TestCheckResourceAttr(resourceName, "blackhole", "false"),
+				),
+			},
+			{
+				Config: provider + testAccIpRoute(`
+	distance = 5
+	routing_table = "isp2"
+	blackhole = true
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testFakeRouterItem(f, "/ip/route", resourceName, map[string]string{
+						"gateway":   "",
+						"blackhole": "yes",
+					}),
+					testFakeRouterLastCommand(f, "/ip/route/set", "=blackhole=yes"),
+					testFakeRouterUnset(f, "/ip/route", "gateway", "check-gateway"),
+					resource.TestCheckResourceAttr(resourceName, "blackhole", "true"),
+				),
+			},
+			{
+				Config: provider + testAccIpRoute(`
+	distance = 5
+	routing_table = "isp2"
+	blackhole = true
+`),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestMikrotikResourceIpRoute_routerOS6(t *testing.T) {
+	f := newFakeRouterVersion("6.49.10 (long-term)")
+	provider := testFakeProvider(newFakeApiServer(t, f))
+	resourceName := "mikrotik_ip_route.autotest"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testFakeRouterEmpty(f, "/ip/route"),
+		Steps: []resource.TestStep{
+			{
+				Config: provider + testAccIpRoute(`
+	routing_table = "isp2"
+	blackhole = true
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testFakeRouterItem(f, "/ip/route", resourceName, map[string]string{
+						"routing-mark":  "isp2",
+						"routing-table": "",
+						"type":          "blackhole",
+						"blackhole":     "",
+					}),
+					testFakeRouterLastCommand(f, "/ip/route/add", "=dst-address=10.99.0.0/16", "=routing-mark=isp2", "=type=blackhole", "=comment=autotest", "=disabled=no"),
+					resource.TestCheckResourceAttr(resourceName, "blackhole", "true"),
+					resource.TestCheckResourceAttr(resourceName, "routing_table", "isp2"),
+				),
+			},
+			{
+				Config: provider + testAccIpRoute(`
+	routing_table = "main"
+	gateway = ["192.168.88.1"]
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testFakeRouterItem(f, "/ip/route", resourceName, map[string]string{
+						"gateway":      "192.168.88.1",
+						"type":         "unicast",
+						"routing-mark": "",
+					}),
+					testFakeRouterLastCommand(f, "/ip/route/set", "=gateway=192.168.88.1", "=type=unicast"),
+					testFakeRouterUnset(f, "/ip/route", "routing-mark"),
+					resource.TestCheckResourceAttr(resourceName, "blackhole", "false"),
+					resource.TestCheckResourceAttr(resourceName, "routing_table", "main"),
+				),
+			},
+			{
+				Config: provider + testAccIpRoute(`
+	routing_table = "main"
+	gateway = ["192.168.88.1"]
+`),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				Check: func(s *terraform.State) error {
+					queried := 0
+					for _, cmd := range f.commands {
+						if cmd[0] == "/system/resource/print" {
+							queried++
+						}
+					}
+					if queried != 1 {
+						return fmt.Errorf("The version should be queried once per provider, it was queried %d times", queried)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestMikrotikResourceIpRoute_routerOS6UnsupportedType(t *testing.T) {
+	f := newFakeRouterVersion("6.49.10 (long-term)")
+	f.tables["/ip/route"] = []map[string]string{
+		{".id": "*8", "dst-address": "10.99.0.0/16", "type": "unreachable", "comment": "autotest"},
+	}
+	provider := testFakeProvider(newFakeApiServer(t, f))
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:        provider + testAccIpRoute(""),
+				ResourceName:  "mikrotik_ip_route.autotest",
+				ImportState:   true,
+				ImportStateId: "*8",
+				ExpectError:   regexp.MustCompile("the ip route `\\*8` to 10.99.0.0/16 is of type unreachable, which is not supported"),
+			},
+		},
+	})
+}
@@ -0,0 +1,67 @@
+package mikrotik
+
+import (
+	"net"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+var ipv6AddressMenu = &mikrotikMenu{
+	path: "/ipv6/address",
+	name: "ipv6 address",
+	item: Ipv6Address{},
+}
+
+func resourceIpv6Address() *schema.Resource {
+	r := ipv6AddressMenu.resource()
+	r.Schema["address"].ValidateFunc = validateIpv6AddressOrPrefix
+	r.Schema["address"].DiffSuppressFunc = suppressGeneratedIpv6Address
+	return r
+}
+
+// suppressGeneratedIpv6Address ignores the difference between the configured
+// address and the one the router generated from it. With eui_64 the router
+// fills in the interface identifier of the configured prefix, with from_pool
+// it fills in a prefix of the pool for the configured interface identifier.
+func suppressGeneratedIpv6Address(k, old, new string, d *schema.ResourceData) bool {
+	generated, generatedNet, err := net.ParseCIDR(old)
+	if err != nil {
+		return false
+	}
+	configured, configuredNet, err := net.ParseCIDR(new)
+	if err != nil {
+		return false
+	}
+	if generatedNet.Mask.String() != configuredNet.Mask.String() {
+		return false
+	}
+
+	if d.Get("eui_64").(bool) {
+		return generatedNet.IP.Equal(configuredNet.IP)
+	}
+	if d.Get("from_pool").(string) != "" {
+		for i, b := range generatedNet.Mask {
+			if generated[i]&^b != configured[i]&^b {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+type Ipv6Address struct {
+	Id               string `mikrotik:".id"`
+	Address          string `mikrotik:"address,required"`
+	Interface        string `mikrotik:"interface,required"`
+	Advertise        *bool  `mikrotik:"advertise,computed"`
+	Eui_64           bool   `mikrotik:"eui-64,default=false"`
+	From_pool        string `mikrotik:"from-pool"`
+	No_dad           bool   `mikrotik:"no-dad,default=false"`
+	Comment          string `mikrotik:"comment"`
+	Disabled         bool   `mikrotik:"disabled,default=false"`
+	Actual_interface string `mikrotik:"actual-interface,readonly"`
+	Dynamic          bool   `mikrotik:"dynamic,readonly"`
+	Invalid          bool   `mikrotik:"invalid,readonly"`
+	Link_local       bool   `mikrotik:"link-local,readonly"`
+}
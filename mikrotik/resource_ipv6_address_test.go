@@ -0,0 +1,234 @@
+package mikrotik
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccMikrotikResourceIpv6Address_create(t *testing.T) {
+	resourceName := "mikrotik_ipv6_address.autotest"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckMenuItemDestroy(ipv6AddressMenu, "mikrotik_ipv6_address"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIpv6Address(`
+	address = "2001:db8:99::1/64"
+	advertise = false
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMenuItemExists(ipv6AddressMenu, resourceName),
+					resource.TestCheckResourceAttr(resourceName, "address", "2001:db8:99::1/64"),
+					resource.TestCheckResourceAttr(resourceName, "advertise", "false"),
+					resource.TestCheckResourceAttr(resourceName, "dynamic", "false"),
+				),
+			},
+			{
+				Config: testAccIpv6Address(`
+	address = "2001:db8:99::/64"
+	eui_64 = true
+	no_dad = true
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMenuItemExists(ipv6AddressMenu, resourceName),
+					resource.TestCheckResourceAttr(resourceName, "eui_64", "true"),
+					resource.TestCheckResourceAttr(resourceName, "no_dad", "true"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"address"},
+			},
+		},
+	})
+}
+
+func testAccIpv6Address(attributes string) string {
+	return fmt.Sprintf(`
+resource "mikrotik_ipv6_address" "autotest" {
+	interface = "ether1"
+	comment = "autotest"
+	%s
+}
+`, attributes)
+}
+
+func TestMikrotikResourceIpv6Address_lifecycle(t *testing.T) {
+	f := newFakeRouter()
+	provider := testFakeProvider(newFakeApiServer(t, f))
+	resourceName := "mikrotik_ipv6_address.autotest"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testFakeRouterEmpty(f, "/ipv6/address"),
+		Steps: []resource.TestStep{
+			{
+				Config: provider + testAccIpv6Address(`
+	address = "2001:db8:99::1/64"
+	no_dad = true
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testFakeRouterItem(f, "/ipv6/address", resourceName, map[string]string{
+						"address":   "2001:db8:99::1/64",
+						"interface": "ether1",
+						"no-dad":    "yes",
+						"eui-64":    "no",
+						"advertise": "",
+					}),
+					resource.TestCheckResourceAttr(resourceName, "advertise", "false"),
+				),
+			},
+			{
+				// the router reads back the flags of the address
+				PreConfig: func() {
+					for _, item := range f.tables["/ipv6/address"] {
+						item["advertise"] = "true"
+						item["actual-interface"] = "bridge1"
+						item["link-local"] = "false"
+						item["dynamic"] = "false"
+					}
+				},
+				Config: provider + testAccIpv6Address(`
+	address = "2001:db8:99::1/64"
+	no_dad = true
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "advertise", "true"),
+					resource.TestCheckResourceAttr(resourceName, "actual_interface", "bridge1"),
+					resource.TestCheckResourceAttr(resourceName, "link_local", "false"),
+				),
+			},
+			{
+				Config: provider + testAccIpv6Address(`
+	address = "2001:db8:99::1/64"
+	no_dad = true
+`),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestMikrotikResourceIpv6Address_eui64(t *testing.T) {
+	f := newFakeRouter()
+	provider := testFakeProvider(newFakeApiServer(t, f))
+	resourceName := "mikrotik_ipv6_address.autotest"
+
+	// the router fills in the interface identifier of the prefix
+	generate := func() {
+		for _, item := range f.tables["/ipv6/address"] {
+			item["address"] = "2001:db8:99::211:22ff:fe33:4455/64"
+		}
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testFakeRouterEmpty(f, "/ipv6/address"),
+		Steps: []resource.TestStep{
+			{
+				Config: provider + testAccIpv6Address(`
+	address = "2001:db8:99::/64"
+	eui_64 = true
+`),
+				Check: testFakeRouterItem(f, "/ipv6/address", resourceName, map[string]string{
+					"address": "2001:db8:99::/64",
+					"eui-64":  "yes",
+				}),
+			},
+			{
+				PreConfig: generate,
+				Config: provider + testAccIpv6Address(`
+	address = "2001:db8:99::/64"
+	eui_64 = true
+`),
+				PlanOnly: true,
+			},
+			{
+				Config: provider + testAccIpv6Address(`
+	address = "2001:db8:42::/64"
+	eui_64 = true
+`),
+				Check: testFakeRouterLastCommand(f, "/ipv6/address/set", "=address=2001:db8:42::/64"),
+			},
+		},
+	})
+}
+
+func TestMikrotikResourceIpv6Address_fromPool(t *testing.T) {
+	f := newFakeRouter()
+	provider := testFakeProvider(newFakeApiServer(t, f))
+	resourceName := "mikrotik_ipv6_address.autotest"
+
+	// the router takes the prefix from the pool
+	generate := func() {
+		for _, item := range f.tables["/ipv6/address"] {
+			item["address"] = "2001:db8:99:1::1/64"
+			item["dynamic"] = "true"
+		}
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testFakeRouterEmpty(f, "/ipv6/address"),
+		Steps: []resource.TestStep{
+			{
+				Config: provider + testAccIpv6Address(`
+	address = "::1/64"
+	from_pool = "isp"
+`),
+				Check: testFakeRouterItem(f, "/ipv6/address", resourceName, map[string]string{
+					"address":   "::1/64",
+					"from-pool": "isp",
+				}),
+			},
+			{
+				PreConfig: generate,
+				Config: provider + testAccIpv6Address(`
+	address = "::1/64"
+	from_pool = "isp"
+`),
+				Check: resource.TestCheckResourceAttr(resourceName, "dynamic", "true"),
+			},
+			{
+				Config: provider + testAccIpv6Address(`
+	address = "::1/64"
+	from_pool = "isp"
+`),
+				PlanOnly: true,
+			},
+			{
+				Config: provider + testAccIpv6Address(`
+	address = "::2/64"
+	from_pool = "isp"
+`),
+				Check: testFakeRouterLastCommand(f, "/ipv6/address/set", "=address=::2/64"),
+			},
+		},
+	})
+}
+
+func TestMikrotikResourceIpv6Address_invalidAddress(t *testing.T) {
+	f := newFakeRouter()
+	provider := testFakeProvider(newFakeApiServer(t, f))
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testFakeRouterEmpty(f, "/ipv6/address"),
+		Steps: []resource.TestStep{
+			{
+				Config: provider + testAccIpv6Address(`
+	address = "192.168.88.1/24"
+`),
+				ExpectError: regexp.MustCompile(`expected address to be an IPv6 address or prefix`),
+			},
+		},
+	})
+}
@@ -0,0 +1,25 @@
+package mikrotik
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+var ipv6FirewallAddressListMenu = &mikrotikMenu{
+	path: "/ipv6/firewall/address-list",
+	name: "ipv6 firewall address-list",
+	item: Ipv6FirewallAddressList{},
+}
+
+func resourceIpv6FirewallAddressList() *schema.Resource {
+	r := ipv6FirewallAddressListMenu.resource()
+	r.Schema["address"].ValidateFunc = validateIpv6AddressOrPrefix
+	return r
+}
+
+type Ipv6FirewallAddressList struct {
+	Id       string `mikrotik:".id"`
+	Address  string `mikrotik:"address,required"`
+	List     string `mikrotik:"list,required"`
+	Comment  string `mikrotik:"comment"`
+	Disabled bool   `mikrotik:"disabled,default=false"`
+}
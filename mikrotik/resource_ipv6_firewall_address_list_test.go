@@ -0,0 +1,90 @@
+package mikrotik
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccMikrotikResourceIpv6FirewallAddressList_create(t *testing.T) {
+	resourceName := "mikrotik_ipv6_firewall_address_list.autotest"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckMenuItemDestroy(ipv6FirewallAddressListMenu, "mikrotik_ipv6_firewall_address_list"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIpv6FirewallAddressList("2001:db8::/32"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMenuItemExists(ipv6FirewallAddressListMenu, resourceName),
+					resource.TestCheckResourceAttr(resourceName, "address", "2001:db8::/32"),
+					resource.TestCheckResourceAttr(resourceName, "list", "list1"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccIpv6FirewallAddressList(address string) string {
+	return fmt.Sprintf(`
+resource "mikrotik_ipv6_firewall_address_list" "autotest" {
+	address = "%s"
+	list = "list1"
+	comment = "autotest"
+}
+`, address)
+}
+
+func TestMikrotikResourceIpv6FirewallAddressList_lifecycle(t *testing.T) {
+	f := newFakeRouter()
+	provider := testFakeProvider(newFakeApiServer(t, f))
+	resourceName := "mikrotik_ipv6_firewall_address_list.autotest"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testFakeRouterEmpty(f, "/ipv6/firewall/address-list"),
+		Steps: []resource.TestStep{
+			{
+				Config: provider + testAccIpv6FirewallAddressList("2001:db8::/32"),
+				Check: testFakeRouterItem(f, "/ipv6/firewall/address-list", resourceName, map[string]string{
+					"address": "2001:db8::/32",
+					"list":    "list1",
+					"comment": "autotest",
+				}),
+			},
+			{
+				Config: provider + testAccIpv6FirewallAddressList("2001:db8::1"),
+				Check:  testFakeRouterLastCommand(f, "/ipv6/firewall/address-list/set", "=address=2001:db8::1"),
+			},
+			{
+				Config:            provider + testAccIpv6FirewallAddressList("2001:db8::1"),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestMikrotikResourceIpv6FirewallAddressList_invalidAddress(t *testing.T) {
+	f := newFakeRouter()
+	provider := testFakeProvider(newFakeApiServer(t, f))
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testFakeRouterEmpty(f, "/ipv6/firewall/address-list"),
+		Steps: []resource.TestStep{
+			{
+				Config:      provider + testAccIpv6FirewallAddressList("192.168.88.1"),
+				ExpectError: regexp.MustCompile(`expected address to be an IPv6 address or prefix`),
+			},
+		},
+	})
+}
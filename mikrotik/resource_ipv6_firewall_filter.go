@@ -0,0 +1,33 @@
+package mikrotik
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+var ipv6FirewallFilterMenu = &mikrotikMenu{
+	path: "/ipv6/firewall/filter",
+	name: "ipv6 firewall filter",
+	item: Ipv6FirewallFilter{},
+}
+
+func resourceIpv6FirewallFilter() *schema.Resource {
+	return ipv6FirewallResource(ipv6FirewallFilterMenu)
+}
+
+type Ipv6FirewallFilter struct {
+	Id                   string `mikrotik:".id"`
+	Action               string `mikrotik:"action,default=accept"`
+	Address_list         string `mikrotik:"address-list"`
+	Address_list_timeout string `mikrotik:"address-list-timeout"`
+	Chain                string `mikrotik:"chain,required"`
+	Comment              string `mikrotik:"comment"`
+	Copy_from            string `mikrotik:"copy-from,writeonly"`
+	Disabled             bool   `mikrotik:"disabled,default=false"`
+	Jump_target          string `mikrotik:"jump-target"`
+	Log                  *bool  `mikrotik:"log"`
+	Log_prefix           string `mikrotik:"log-prefix"`
+	Place_before         string `mikrotik:"place-before,writeonly,move"`
+	Reject_with          string `mikrotik:"reject-with"`
+	Dynamic              bool   `mikrotik:"dynamic,readonly"`
+	Ipv6FirewallMatchers
+}
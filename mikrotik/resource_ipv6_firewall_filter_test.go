@@ -0,0 +1,109 @@
+package mikrotik
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccMikrotikResourceIpv6FirewallFilter_create(t *testing.T) {
+	resourceName := "mikrotik_ipv6_firewall_filter.autotest"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckMenuItemDestroy(ipv6FirewallFilterMenu, "mikrotik_ipv6_firewall_filter"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIpv6FirewallFilter("accept", "2001:db8::/32"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMenuItemExists(ipv6FirewallFilterMenu, resourceName),
+					resource.TestCheckResourceAttr(resourceName, "action", "accept"),
+					resource.TestCheckResourceAttr(resourceName, "src_address", "2001:db8::/32"),
+				),
+			},
+			{
+				Config: testAccIpv6FirewallFilter("drop", "!2001:db8::/32"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMenuItemExists(ipv6FirewallFilterMenu, resourceName),
+					resource.TestCheckResourceAttr(resourceName, "action", "drop"),
+					resource.TestCheckResourceAttr(resourceName, "src_address", "!2001:db8::/32"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccIpv6FirewallFilter(action, srcAddress string) string {
+	return fmt.Sprintf(`
+resource "mikrotik_ipv6_firewall_filter" "autotest" {
+	chain = "input"
+	action = "%s"
+	protocol = "icmpv6"
+	src_address = "%s"
+	hop_limit = "equal:255"
+	comment = "autotest"
+}
+`, action, srcAddress)
+}
+
+func TestMikrotikResourceIpv6FirewallFilter_lifecycle(t *testing.T) {
+	f := newFakeRouter()
+	provider := testFakeProvider(newFakeApiServer(t, f))
+	resourceName := "mikrotik_ipv6_firewall_filter.autotest"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testFakeRouterEmpty(f, "/ipv6/firewall/filter"),
+		Steps: []resource.TestStep{
+			{
+				Config: provider + testAccIpv6FirewallFilter("accept", "2001:db8::/32"),
+				Check: testFakeRouterItem(f, "/ipv6/firewall/filter", resourceName, map[string]string{
+					"chain":       "input",
+					"action":      "accept",
+					"protocol":    "icmpv6",
+					"src-address": "2001:db8::/32",
+					"hop-limit":   "equal:255",
+				}),
+			},
+			{
+				Config: provider + testAccIpv6FirewallFilter("drop", "!2001:db8::/32"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testFakeRouterItem(f, "/ipv6/firewall/filter", resourceName, map[string]string{
+						"action":      "drop",
+						"src-address": "!2001:db8::/32",
+					}),
+					testFakeRouterLastCommand(f, "/ipv6/firewall/filter/set", "=action=drop", "=src-address=!2001:db8::/32"),
+				),
+			},
+			{
+				Config:            provider + testAccIpv6FirewallFilter("drop", "!2001:db8::/32"),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestMikrotikResourceIpv6FirewallFilter_invalidAddress(t *testing.T) {
+	f := newFakeRouter()
+	provider := testFakeProvider(newFakeApiServer(t, f))
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testFakeRouterEmpty(f, "/ipv6/firewall/filter"),
+		Steps: []resource.TestStep{
+			{
+				Config:      provider + testAccIpv6FirewallFilter("accept", "192.168.88.0/24"),
+				ExpectError: regexp.MustCompile(`expected src_address to be an IPv6 address, prefix or range`),
+			},
+		},
+	})
+}
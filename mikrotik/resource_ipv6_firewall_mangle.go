@@ -0,0 +1,60 @@
+package mikrotik
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+var ipv6FirewallMangleMenu = &mikrotikMenu{
+	path: "/ipv6/firewall/mangle",
+	name: "ipv6 firewall mangle",
+	item: Ipv6FirewallMangle{},
+}
+
+var ipv6FirewallMangleActions = []string{
+	"accept",
+	"add-dst-to-address-list",
+	"add-src-to-address-list",
+	"change-dscp",
+	"change-hop-limit",
+	"change-mss",
+	"jump",
+	"log",
+	"mark-connection",
+	"mark-packet",
+	"mark-routing",
+	"passthrough",
+	"return",
+	"set-priority",
+}
+
+func resourceIpv6FirewallMangle() *schema.Resource {
+	r := ipv6FirewallResource(ipv6FirewallMangleMenu)
+	r.Schema["action"].ValidateFunc = validation.StringInSlice(ipv6FirewallMangleActions, false)
+	return r
+}
+
+type Ipv6FirewallMangle struct {
+	Id                   string `mikrotik:".id"`
+	Action               string `mikrotik:"action,default=accept"`
+	Address_list         string `mikrotik:"address-list"`
+	Address_list_timeout string `mikrotik:"address-list-timeout"`
+	Chain                string `mikrotik:"chain,required"`
+	Comment              string `mikrotik:"comment"`
+	Copy_from            string `mikrotik:"copy-from,writeonly"`
+	Disabled             bool   `mikrotik:"disabled,default=false"`
+	Jump_target          string `mikrotik:"jump-target"`
+	Log                  *bool  `mikrotik:"log"`
+	Log_prefix           string `mikrotik:"log-prefix"`
+	New_connection_mark  string `mikrotik:"new-connection-mark"`
+	New_dscp             string `mikrotik:"new-dscp"`
+	New_hop_limit        string `mikrotik:"new-hop-limit"`
+	New_mss              string `mikrotik:"new-mss"`
+	New_packet_mark      string `mikrotik:"new-packet-mark"`
+	New_priority         string `mikrotik:"new-priority"`
+	New_routing_mark     string `mikrotik:"new-routing-mark"`
+	Passthrough          bool   `mikrotik:"passthrough,default=true"`
+	Place_before         string `mikrotik:"place-before,writeonly,move"`
+	Dynamic              bool   `mikrotik:"dynamic,readonly"`
+	Ipv6FirewallMatchers
+}
@@ -0,0 +1,77 @@
+package mikrotik
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccMikrotikResourceIpv6FirewallMangle_create(t *testing.T) {
+	resourceName := "mikrotik_ipv6_firewall_mangle.autotest"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckMenuItemDestroy(ipv6FirewallMangleMenu, "mikrotik_ipv6_firewall_mangle"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIpv6FirewallMangle("isp2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMenuItemExists(ipv6FirewallMangleMenu, resourceName),
+					resource.TestCheckResourceAttr(resourceName, "action", "mark-routing"),
+					resource.TestCheckResourceAttr(resourceName, "new_routing_mark", "isp2"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccIpv6FirewallMangle(mark string) string {
+	return fmt.Sprintf(`
+resource "mikrotik_ipv6_firewall_mangle" "autotest" {
+	chain = "prerouting"
+	action = "mark-routing"
+	dst_address = "2001:db8::1-2001:db8::ff"
+	new_routing_mark = "%s"
+	passthrough = false
+	comment = "autotest"
+}
+`, mark)
+}
+
+func TestMikrotikResourceIpv6FirewallMangle_lifecycle(t *testing.T) {
+	f := newFakeRouter()
+	provider := testFakeProvider(newFakeApiServer(t, f))
+	resourceName := "mikrotik_ipv6_firewall_mangle.autotest"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testFakeRouterEmpty(f, "/ipv6/firewall/mangle"),
+		Steps: []resource.TestStep{
+			{
+				Config: provider + testAccIpv6FirewallMangle("isp2"),
+				Check: testFakeRouterItem(f, "/ipv6/firewall/mangle", resourceName, map[string]string{
+					"action":           "mark-routing",
+					"dst-address":      "2001:db8::1-2001:db8::ff",
+					"new-routing-mark": "isp2",
+					"passthrough":      "no",
+				}),
+			},
+			{
+				Config: provider + testAccIpv6FirewallMangle("isp3"),
+				Check:  testFakeRouterLastCommand(f, "/ipv6/firewall/mangle/set", "=new-routing-mark=isp3"),
+			},
+			{
+				Config:            provider + testAccIpv6FirewallMangle("isp3"),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,55 @@
+package mikrotik
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+var ipv6FirewallNatMenu = &mikrotikMenu{
+	path: "/ipv6/firewall/nat",
+	name: "ipv6 firewall nat",
+	item: Ipv6FirewallNat{},
+}
+
+// ipv6FirewallNatActions are the actions of the IPv6 nat table, available
+// since RouterOS 7.
+var ipv6FirewallNatActions = []string{
+	"accept",
+	"add-dst-to-address-list",
+	"add-src-to-address-list",
+	"dst-nat",
+	"jump",
+	"log",
+	"masquerade",
+	"netmap",
+	"passthrough",
+	"redirect",
+	"return",
+	"src-nat",
+}
+
+func resourceIpv6FirewallNat() *schema.Resource {
+	r := ipv6FirewallResource(ipv6FirewallNatMenu)
+	r.Schema["action"].ValidateFunc = validation.StringInSlice(ipv6FirewallNatActions, false)
+	r.Schema["to_address"].ValidateFunc = validateIpv6AddressOrPrefix
+	return r
+}
+
+type Ipv6FirewallNat struct {
+	Id                   string `mikrotik:".id"`
+	Action               string `mikrotik:"action,default=accept"`
+	Address_list         string `mikrotik:"address-list"`
+	Address_list_timeout string `mikrotik:"address-list-timeout"`
+	Chain                string `mikrotik:"chain,required"`
+	Comment              string `mikrotik:"comment"`
+	Copy_from            string `mikrotik:"copy-from,writeonly"`
+	Disabled             bool   `mikrotik:"disabled,default=false"`
+	Jump_target          string `mikrotik:"jump-target"`
+	Log                  *bool  `mikrotik:"log"`
+	Log_prefix           string `mikrotik:"log-prefix"`
+	Place_before         string `mikrotik:"place-before,writeonly,move"`
+	To_address           string `mikrotik:"to-address"`
+	To_ports             string `mikrotik:"to-ports"`
+	Dynamic              bool   `mikrotik:"dynamic,readonly"`
+	Ipv6FirewallMatchers
+}
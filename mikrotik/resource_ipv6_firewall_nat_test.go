@@ -0,0 +1,77 @@
+package mikrotik
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccMikrotikResourceIpv6FirewallNat_create(t *testing.T) {
+	resourceName := "mikrotik_ipv6_firewall_nat.autotest"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckMenuItemDestroy(ipv6FirewallNatMenu, "mikrotik_ipv6_firewall_nat"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIpv6FirewallNat("2001:db8:1::/48"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMenuItemExists(ipv6FirewallNatMenu, resourceName),
+					resource.TestCheckResourceAttr(resourceName, "action", "netmap"),
+					resource.TestCheckResourceAttr(resourceName, "to_address", "2001:db8:1::/48"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccIpv6FirewallNat(toAddress string) string {
+	return fmt.Sprintf(`
+resource "mikrotik_ipv6_firewall_nat" "autotest" {
+	chain = "srcnat"
+	action = "netmap"
+	src_address = "fd00::/48"
+	to_address = "%s"
+	out_interface = "ether1"
+	comment = "autotest"
+}
+`, toAddress)
+}
+
+func TestMikrotikResourceIpv6FirewallNat_lifecycle(t *testing.T) {
+	f := newFakeRouter()
+	provider := testFakeProvider(newFakeApiServer(t, f))
+	resourceName := "mikrotik_ipv6_firewall_nat.autotest"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testFakeRouterEmpty(f, "/ipv6/firewall/nat"),
+		Steps: []resource.TestStep{
+			{
+				Config: provider + testAccIpv6FirewallNat("2001:db8:1::/48"),
+				Check: testFakeRouterItem(f, "/ipv6/firewall/nat", resourceName, map[string]string{
+					"chain":       "srcnat",
+					"action":      "netmap",
+					"src-address": "fd00::/48",
+					"to-address":  "2001:db8:1::/48",
+				}),
+			},
+			{
+				Config: provider + testAccIpv6FirewallNat("2001:db8:2::/48"),
+				Check:  testFakeRouterLastCommand(f, "/ipv6/firewall/nat/set", "=to-address=2001:db8:2::/48"),
+			},
+			{
+				Config:            provider + testAccIpv6FirewallNat("2001:db8:2::/48"),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,37 @@
+package mikrotik
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+var ipv6RouteMenu = &mikrotikMenu{
+	path: "/ipv6/route",
+	name: "ipv6 route",
+	item: Ipv6Route{},
+}
+
+func resourceIpv6Route() *schema.Resource {
+	r := ipv6RouteMenu.resource()
+	r.Schema["dst_address"].ValidateFunc = validateIpv6AddressOrPrefix
+	r.Schema["check_gateway"].ValidateFunc = validation.StringInSlice([]string{"bfd", "bfd-multihop", "none", "ping"}, false)
+	return r
+}
+
+// Ipv6Route is a static IPv6 route. Unlike IPv4 routes, RouterOS 6 has no
+// blackhole IPv6 routes, so blackhole is only sent when it is configured.
+type Ipv6Route struct {
+	Id            string   `mikrotik:".id"`
+	Dst_address   string   `mikrotik:"dst-address,required"`
+	Gateway       []string `mikrotik:"gateway"`
+	Distance      int      `mikrotik:"distance,computed"`
+	Scope         int      `mikrotik:"scope,computed"`
+	Target_scope  int      `mikrotik:"target-scope,computed"`
+	Routing_table string   `mikrotik:"routing-table,computed"`
+	Check_gateway string   `mikrotik:"check-gateway"`
+	Blackhole     *bool    `mikrotik:"blackhole"`
+	Comment       string   `mikrotik:"comment"`
+	Disabled      bool     `mikrotik:"disabled,default=false"`
+	Active        bool     `mikrotik:"active,readonly"`
+	Dynamic       bool     `mikrotik:"dynamic,readonly"`
+}
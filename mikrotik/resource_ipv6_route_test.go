@@ -0,0 +1,91 @@
+package mikrotik
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccMikrotikResourceIpv6Route_create(t *testing.T) {
+	resourceName := "mikrotik_ipv6_route.autotest"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckMenuItemDestroy(ipv6RouteMenu, "mikrotik_ipv6_route"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIpv6Route(`
+	gateway = ["2001:db8::1"]
+	distance = 5
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMenuItemExists(ipv6RouteMenu, resourceName),
+					resource.TestCheckResourceAttr(resourceName, "dst_address", "2001:db8:99::/48"),
+					resource.TestCheckResourceAttr(resourceName, "gateway.0", "2001:db8::1"),
+					resource.TestCheckResourceAttr(resourceName, "distance", "5"),
+					resource.TestCheckResourceAttr(resourceName, "dynamic", "false"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccIpv6Route(attributes string) string {
+	return fmt.Sprintf(`
+resource "mikrotik_ipv6_route" "autotest" {
+	dst_address = "2001:db8:99::/48"
+	comment = "autotest"
+	%s
+}
+`, attributes)
+}
+
+func TestMikrotikResourceIpv6Route_lifecycle(t *testing.T) {
+	f := newFakeRouter()
+	provider := testFakeProvider(newFakeApiServer(t, f))
+	resourceName := "mikrotik_ipv6_route.autotest"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testFakeRouterEmpty(f, "/ipv6/route"),
+		Steps: []resource.TestStep{
+			{
+				Config: provider + testAccIpv6Route(`
+	gateway = ["fe80::1%ether1"]
+	check_gateway = "ping"
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testFakeRouterLastCommand(f, "/ipv6/route/add", "=dst-address=2001:db8:99::/48", "=gateway=fe80::1%ether1", "=check-gateway=ping", "=comment=autotest", "=disabled=no"),
+					resource.TestCheckResourceAttr(resourceName, "gateway.0", "fe80::1%ether1"),
+					resource.TestCheckResourceAttr(resourceName, "blackhole", "false"),
+				),
+			},
+			{
+				Config: provider + testAccIpv6Route(`
+	blackhole = true
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testFakeRouterItem(f, "/ipv6/route", resourceName, map[string]string{
+						"gateway":   "",
+						"blackhole": "yes",
+					}),
+					resource.TestCheckResourceAttr(resourceName, "blackhole", "true"),
+				),
+			},
+			{
+				Config: provider + testAccIpv6Route(`
+	blackhole = true
+`),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,109 @@
+package mikrotik
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/go-routeros/routeros"
+)
+
+const (
+	apiTransportName  = "api"
+	restTransportName = "rest"
+)
+
+// mikrotikTransport sends API sentences such as `/ip/address/print ?.id=*1`
+// to the router and returns the reply in the form of the binary API, so
+// resources do not need to know which protocol is in use.
+type mikrotikTransport interface {
+	// run runs a single attempt of cmd. stale reports whether the command
+	// failed because a previously opened connection was no longer usable.
+	run(client mikrotikConfig, cmd []string) (r *routeros.Reply, stale bool, err error)
+	close()
+}
+
+func newTransport(name string) (mikrotikTransport, error) {
+	switch name {
+	case apiTransportName, "":
+		return &apiTransport{}, nil
+	case restTransportName:
+		return &restTransport{}, nil
+	}
+	return nil, fmt.Errorf("unsupported transport `%s` for the mikrotik provider", name)
+}
+
+// apiTransport holds the binary API session shared by every copy of a
+// mikrotikConfig. The routeros client is not safe for concurrent use so
+// commands are serialized through mu.
+type apiTransport struct {
+	mu     sync.Mutex
+	client *routeros.Client
+	conn   net.Conn
+}
+
+func (t *apiTransport) run(client mikrotikConfig, cmd []string) (r *routeros.Reply, stale bool, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	reused := t.client != nil
+	if reused && !isReadOnlyCommand(cmd) && !t.alive(client) {
+		reused = false
+	}
+	if t.client == nil {
+		t.client, t.conn, err = client.dial()
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	t.conn.SetDeadline(client.commandDeadline())
+	logCommand(cmd)
+	r, err = t.client.RunArgs(cmd)
+	t.conn.SetDeadline(time.Time{})
+
+	if isSessionError(err) {
+		log.Printf("[WARN] Closing mikrotik api session after error: %v", err)
+		t.client.Close()
+		t.client = nil
+		t.conn = nil
+		return r, reused, err
+	}
+
+	return r, false, err
+}
+
+// apiSessionProbe is the command checking that a reused session is alive.
+var apiSessionProbe = []string{"/system/identity/print"}
+
+// alive checks that the session still works before a command changing the
+// router is sent on it: such a command is not run again when the session
+// turns out to be dead, as it may have been delivered. A dead session is
+// closed so that the command is sent on a new one.
+func (t *apiTransport) alive(client mikrotikConfig) bool {
+	t.conn.SetDeadline(client.commandDeadline())
+	_, err := t.client.RunArgs(apiSessionProbe)
+	t.conn.SetDeadline(time.Time{})
+
+	if isSessionError(err) {
+		log.Printf("[WARN] Reconnecting the mikrotik api session after error: %v", err)
+		t.client.Close()
+		t.client = nil
+		t.conn = nil
+		return false
+	}
+	return true
+}
+
+func (t *apiTransport) close() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.client != nil {
+		t.client.Close()
+		t.client = nil
+		t.conn = nil
+	}
+}
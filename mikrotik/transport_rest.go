@@ -0,0 +1,212 @@
+package mikrotik
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/go-routeros/routeros"
+	"github.com/go-routeros/routeros/proto"
+)
+
+// restTransport talks to the `/rest` endpoint of RouterOS 7. Every API
+// sentence is sent as a POST to the matching console command, which the
+// REST API accepts for all menus, e.g. `/ip/address/print ?.id=*1` becomes
+// `POST /rest/ip/address/print` with `{".query": [".id=*1"]}`.
+type restTransport struct {
+	mu         sync.Mutex
+	httpClient *http.Client
+}
+
+func (t *restTransport) run(client mikrotikConfig, cmd []string) (*routeros.Reply, bool, error) {
+	httpClient, err := t.getHttpClient(client)
+	if err != nil {
+		return nil, false, err
+	}
+
+	path, body := restCommand(cmd)
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, false, err
+	}
+
+	ctx := context.Background()
+	if deadline := client.commandDeadline(); !deadline.IsZero() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, deadline)
+		defer cancel()
+	}
+
+	url := fmt.Sprintf("%s://%s/rest%s", client.restScheme(), client.Host, path)
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, false, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(client.Username, client.Password)
+
+	logCommand(cmd)
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, false, err
+	}
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, false, err
+	}
+
+	r, err := restReply(resp.StatusCode, data)
+	return r, false, err
+}
+
+func (t *restTransport) close() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.httpClient != nil {
+		t.httpClient.CloseIdleConnections()
+	}
+}
+
+func (t *restTransport) getHttpClient(client mikrotikConfig) (*http.Client, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.httpClient != nil {
+		return t.httpClient, nil
+	}
+
+	dialer := &net.Dialer{Timeout: client.ConnectTimeout}
+	transport := &http.Transport{
+		Proxy:               http.ProxyFromEnvironment,
+		DialContext:         dialer.DialContext,
+		TLSHandshakeTimeout: client.ConnectTimeout,
+	}
+	if client.TLS {
+		tlsConfig, err := client.tlsConfig()
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	t.httpClient = &http.Client{Transport: transport}
+	return t.httpClient, nil
+}
+
+func (client mikrotikConfig) restScheme() string {
+	if client.TLS {
+		return "https"
+	}
+	return "http"
+}
+
+// restCommand converts an API sentence into the path and JSON body of the
+// equivalent REST call. Attribute words (`=name=value`) become properties of
+// the body and query words (`?name=value`) are collected in `.query`.
+func restCommand(cmd []string) (string, map[string]interface{}) {
+	body := map[string]interface{}{}
+	var query []string
+
+	for _, word := range cmd[1:] {
+		switch {
+		case strings.HasPrefix(word, "="):
+			pair := strings.SplitN(word[1:], "=", 2)
+			if len(pair) == 2 {
+				body[pair[0]] = pair[1]
+			} else {
+				body[pair[0]] = ""
+			}
+		case strings.HasPrefix(word, "?"):
+			query = append(query, word[1:])
+		}
+	}
+
+	if len(query) > 0 {
+		body[".query"] = query
+	}
+
+	return cmd[0], body
+}
+
+// restReply converts a REST response into the reply the binary API would
+// have returned. Client errors are reported as `!trap` device errors so
+// callers handle both transports the same way.
+func restReply(status int, data []byte) (*routeros.Reply, error) {
+	if status >= 400 {
+		restErr := struct {
+			Message string `json:"message"`
+			Detail  string `json:"detail"`
+		}{}
+		json.Unmarshal(data, &restErr)
+
+		message := restErr.Detail
+		if message == "" {
+			message = restErr.Message
+		}
+		if message == "" {
+			message = http.StatusText(status)
+		}
+
+		if status >= 500 {
+			return nil, fmt.Errorf("RouterOS REST API returned %d: %s", status, message)
+		}
+		return nil, &routeros.DeviceError{Sentence: newRestSentence("!trap", map[string]interface{}{"message": message})}
+	}
+
+	r := &routeros.Reply{Done: newRestSentence("!done", nil)}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return r, nil
+	}
+
+	var decoded interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		// the response is left out, as it may carry secrets which cannot
+		// be redacted from it
+		return nil, fmt.Errorf("failed to decode RouterOS REST API response of %d bytes: %v", len(data), err)
+	}
+
+	switch v := decoded.(type) {
+	case []interface{}:
+		for _, item := range v {
+			attributes, ok := item.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("unexpected item in RouterOS REST API response: %v", item)
+			}
+			r.Re = append(r.Re, newRestSentence("!re", attributes))
+		}
+	case map[string]interface{}:
+		r.Done = newRestSentence("!done", v)
+	}
+
+	return r, nil
+}
+
+func newRestSentence(word string, attributes map[string]interface{}) *proto.Sentence {
+	sentence := proto.NewSentence()
+	sentence.Word = word
+
+	keys := make([]string, 0, len(attributes))
+	for k := range attributes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		value := fmt.Sprint(attributes[k])
+		sentence.List = append(sentence.List, proto.Pair{Key: k, Value: value})
+		sentence.Map[k] = value
+	}
+
+	return sentence
+}
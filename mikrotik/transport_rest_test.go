@@ -0,0 +1,275 @@
+package mikrotik
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-routeros/routeros"
+)
+
+// newTestRestServer serves the RouterOS 7 REST API on top of a fakeRouter,
+// accepting the admin/password credentials.
+func newTestRestServer(t *testing.T, f *fakeRouter, useTLS bool) *httptest.Server {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, ok := r.BasicAuth()
+		if !ok || username != "admin" || password != "password" {
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode(map[string]interface{}{"error": 401, "message": "Unauthorized"})
+			return
+		}
+
+		if r.Method != http.MethodPost || !strings.HasPrefix(r.URL.Path, "/rest/") {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]interface{}{"error": 400, "message": "Bad Request", "detail": "unsupported request"})
+			return
+		}
+
+		body := map[string]interface{}{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("REST request body is not valid JSON: %v", err)
+		}
+
+		cmd := []string{strings.TrimPrefix(r.URL.Path, "/rest")}
+		for k, v := range body {
+			if k == ".query" {
+				for _, q := range v.([]interface{}) {
+					cmd = append(cmd, "?"+q.(string))
+				}
+				continue
+			}
+			cmd = append(cmd, "="+k+"="+v.(string))
+		}
+
+		reply, err := f.run(cmd)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"error":   400,
+				"message": "Bad Request",
+				"detail":  err.(*routeros.DeviceError).Sentence.Map["message"],
+			})
+			return
+		}
+
+		if strings.HasSuffix(cmd[0], "/print") {
+			items := []map[string]string{}
+			for _, re := range reply.Re {
+				items = append(items, re.Map)
+			}
+			json.NewEncoder(w).Encode(items)
+			return
+		}
+		if ret, ok := reply.Done.Map["ret"]; ok {
+			json.NewEncoder(w).Encode(map[string]string{"ret": ret})
+			return
+		}
+		w.Write([]byte("[]"))
+	})
+
+	var server *httptest.Server
+	if useTLS {
+		server = httptest.NewTLSServer(handler)
+	} else {
+		server = httptest.NewServer(handler)
+	}
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func testRestClient(t *testing.T, server *httptest.Server) mikrotikConfig {
+	c := NewClient(strings.TrimPrefix(strings.TrimPrefix(server.URL, "https://"), "http://"), "admin", "password")
+	c.Transport = restTransportName
+	c.TLS = strings.HasPrefix(server.URL, "https://")
+	c.Insecure = c.TLS
+	c.MaxRetries = 0
+
+	var err error
+	if c.transport, err = newTransport(c.Transport); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(c.Close)
+
+	return c
+}
+
+func TestMikrotikTransportRest_RestCommand(t *testing.T) {
+	path, body := restCommand([]string{"/ip/address/print", "?.id=*1", "?interface=ether1", "=.proplist=address"})
+
+	if path != "/ip/address/print" {
+		t.Errorf("Expected path /ip/address/print, received %s", path)
+	}
+	expected := map[string]interface{}{
+		".proplist": "address",
+		".query":    []string{".id=*1", "interface=ether1"},
+	}
+	if !reflect.DeepEqual(body, expected) {
+		t.Errorf("Unexpected body %v, expected %v", body, expected)
+	}
+
+	_, body = restCommand([]string{"/ip/address/set", "=.id=*1", "=comment=", "=address=10.0.0.1/24"})
+	expected = map[string]interface{}{
+		".id":     "*1",
+		"comment": "",
+		"address": "10.0.0.1/24",
+	}
+	if !reflect.DeepEqual(body, expected) {
+		t.Errorf("Unexpected body %v, expected %v", body, expected)
+	}
+}
+
+func TestMikrotikTransportRest_RestReply(t *testing.T) {
+	r, err := restReply(200, []byte(`[{".id":"*1","address":"10.0.0.1/24","disabled":"false"}]`))
+	if err != nil {
+		t.Fatalf("Failed to convert reply with error: %v", err)
+	}
+	if len(r.Re) != 1 || r.Re[0].Map["address"] != "10.0.0.1/24" || r.Re[0].List[0].Key != ".id" {
+		t.Errorf("Unexpected reply %v", r)
+	}
+
+	r, err = restReply(201, []byte(`{"ret":"*A"}`))
+	if err != nil || r.Done.Map["ret"] != "*A" {
+		t.Errorf("Expected ret of an add to be returned in the done sentence, received %v %v", r, err)
+	}
+
+	_, err = restReply(400, []byte(`{"error":400,"message":"Bad Request","detail":"failure: already have such address"}`))
+	deviceErr, ok := err.(*routeros.DeviceError)
+	if !ok || deviceErr.Sentence.Map["message"] != "failure: already have such address" {
+		t.Errorf("Expected a device error with the detail as message, received %v", err)
+	}
+	if isRetryableError([]string{"/ip/address/print"}, err) {
+		t.Error("A bad request should not be retried")
+	}
+
+	_, err = restReply(200, []byte(`[{"name":"wg1","private-key":"s3cr3t"`))
+	if err == nil || strings.Contains(err.Error(), "s3cr3t") {
+		t.Errorf("Expected an error without the secret of the response, received %v", err)
+	}
+
+	_, err = restReply(503, []byte(``))
+	if err == nil || !isRetryableError([]string{"/ip/address/print"}, err) {
+		t.Errorf("Expected a retryable error for a server error, received %v", err)
+	}
+}
+
+func TestMikrotikTransportRest_Unauthorized(t *testing.T) {
+	server := newTestRestServer(t, newFakeRouter(), false)
+	c := testRestClient(t, server)
+	c.Password = "wrong"
+
+	_, err := c.Run([]string{"/ip/address/print"})
+	if err == nil || !strings.Contains(err.Error(), "Unauthorized") {
+		t.Errorf("Expected an unauthorized error, received %v", err)
+	}
+}
+
+func TestMikrotikTransportRest_IpAddress(t *testing.T) {
+	server := newTestRestServer(t, newFakeRouter(), true)
+	c := testRestClient(t, server)
+
+	ipaddr, err := c.AddIpAddress("10.0.0.1/24", "ether1")
+	if err != nil {
+		t.Fatalf("Error creating an ip address over rest with: %v", err)
+	}
+	if ipaddr.Address != "10.0.0.1/24" || ipaddr.Interface != "ether1" {
+		t.Errorf("Unexpected ip address %v", ipaddr)
+	}
+
+	ipaddr, err = c.UpdateIpAddress(ipaddr.Id, "10.0.1.1/24", "ether2")
+	if err != nil {
+		t.Fatalf("Error updating an ip address over rest with: %v", err)
+	}
+	if ipaddr.Address != "10.0.1.1/24" || ipaddr.Interface != "ether2" {
+		t.Errorf("Unexpected ip address %v", ipaddr)
+	}
+
+	if err := c.DeleteIpAddress(ipaddr.Id); err != nil {
+		t.Fatalf("Error deleting an ip address over rest with: %v", err)
+	}
+	if _, err := c.FindIpAddress(ipaddr.Id); err == nil {
+		t.Error("The deleted ip address should not be found")
+	}
+	if err := c.DeleteIpAddress(ipaddr.Id); err == nil {
+		t.Error("Deleting a missing ip address should fail")
+	}
+}
+
+func TestMikrotikTransportRest_IpFirewallAddressList(t *testing.T) {
+	server := newTestRestServer(t, newFakeRouter(), false)
+	c := testRestClient(t, server)
+
+	addresslist, err := c.AddIpFirewallAddressList("10.0.0.1", "blocklist", "comment", false, "1d")
+	if err != nil {
+		t.Fatalf("Error creating an address list entry over rest with: %v", err)
+	}
+	if addresslist.Address != "10.0.0.1" || addresslist.List != "blocklist" || addresslist.Timeout != "1d" {
+		t.Errorf("Unexpected address list entry %v", addresslist)
+	}
+
+	addresslist, err = c.UpdateIpFirewallAddressList(addresslist.Id, "10.0.0.2", "blocklist", "updated", true, "")
+	if err != nil {
+		t.Fatalf("Error updating an address list entry over rest with: %v", err)
+	}
+	if addresslist.Address != "10.0.0.2" || addresslist.Comment != "updated" {
+		t.Errorf("Unexpected address list entry %v", addresslist)
+	}
+
+	if err := c.DeleteIpFirewallAddressList(addresslist.Id); err != nil {
+		t.Fatalf("Error deleting an address list entry over rest with: %v", err)
+	}
+}
+
+func TestMikrotikTransportRest_InterfaceGre(t *testing.T) {
+	server := newTestRestServer(t, newFakeRouter(), false)
+	c := testRestClient(t, server)
+
+	greif, err := c.AddInterfaceGre(true, true, "comment", "", false, "no", "inherit", "", "10s,10", "", "auto", "gre-rest", "1.1.1.1")
+	if err != nil {
+		t.Fatalf("Error creating a gre interface over rest with: %v", err)
+	}
+	if greif.Name != "gre-rest" || greif.Comment != "comment" {
+		t.Errorf("Unexpected gre interface %v", greif)
+	}
+
+	greif, err = c.UpdateInterfaceGre(greif.Id, true, true, "updated", "", false, "no", "inherit", "", "10s,10", "", "auto", "gre-rest2", "2.2.2.2")
+	if err != nil {
+		t.Fatalf("Error updating a gre interface over rest with: %v", err)
+	}
+	if greif.Name != "gre-rest2" || greif.Comment != "updated" {
+		t.Errorf("Unexpected gre interface %v", greif)
+	}
+
+	if err := c.DeleteInterfaceGre(greif.Id); err != nil {
+		t.Fatalf("Error deleting a gre interface over rest with: %v", err)
+	}
+}
+
+func TestMikrotikTransportRest_IpFirewallFilter(t *testing.T) {
+	server := newTestRestServer(t, newFakeRouter(), false)
+	c := testRestClient(t, server)
+
+	filter, err := c.AddIpFirewallFilter(&IpFirewallFilter{Chain: "forward", Action: "accept"})
+	if err != nil {
+		t.Fatalf("Error creating a firewall filter over rest with: %v", err)
+	}
+	if filter.Chain != "forward" || filter.Action != "accept" {
+		t.Errorf("Unexpected firewall filter %v", filter)
+	}
+
+	filter.Action = "drop"
+	filter, err = c.UpdateIpFirewallFilter(filter.Id, filter)
+	if err != nil {
+		t.Fatalf("Error updating a firewall filter over rest with: %v", err)
+	}
+	if filter.Action != "drop" {
+		t.Errorf("Unexpected firewall filter %v", filter)
+	}
+
+	if err := c.DeleteIpFirewallFilter(filter.Id); err != nil {
+		t.Fatalf("Error deleting a firewall filter over rest with: %v", err)
+	}
+}
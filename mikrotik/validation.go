@@ -0,0 +1,122 @@
+package mikrotik
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// validateDuration accepts a RouterOS duration, such as 1d or 00:05:00, see
+// parseMikrotikDuration.
+func validateDuration(v interface{}, k string) ([]string, []error) {
+	value, ok := v.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+
+	if _, err := ttlToSeconds(value); err != nil {
+		return nil, []error{fmt.Errorf("expected %s to be a duration, such as 1d or 12h, got %q: %v", k, value, err)}
+	}
+	return nil, nil
+}
+
+// validateIpv4AddressPrefix accepts an IPv4 address with the length of its
+// prefix, such as 192.168.88.1/24.
+func validateIpv4AddressPrefix(v interface{}, k string) ([]string, []error) {
+	value, ok := v.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+
+	if ip, _, err := net.ParseCIDR(value); err != nil || ip.To4() == nil {
+		return nil, []error{fmt.Errorf("expected %s to be an IPv4 address with a prefix length, such as 192.168.88.1/24, got %q", k, value)}
+	}
+	return nil, nil
+}
+
+// validateIpPrefix accepts an IPv4 or IPv6 address with the length of its
+// prefix, such as 10.0.0.0/24 or 2001:db8::/64.
+func validateIpPrefix(v interface{}, k string) ([]string, []error) {
+	value, ok := v.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+
+	if _, _, err := net.ParseCIDR(value); err != nil {
+		return nil, []error{fmt.Errorf("expected %s to be an address with a prefix length, such as 10.0.0.0/24, got %q", k, value)}
+	}
+	return nil, nil
+}
+
+// validateIpv6AddressOrPrefix accepts an IPv6 address, such as 2001:db8::1,
+// or prefix, such as 2001:db8::/32.
+func validateIpv6AddressOrPrefix(v interface{}, k string) ([]string, []error) {
+	value, ok := v.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+
+	if !isIpv6AddressOrPrefix(value) {
+		return nil, []error{fmt.Errorf("expected %s to be an IPv6 address or prefix, got %q", k, value)}
+	}
+	return nil, nil
+}
+
+// validateIpv6AddressMatcher accepts the IPv6 address matchers of firewall
+// rules: an address, a prefix or a range of addresses such as
+// 2001:db8::1-2001:db8::ff, negated by a leading `!`.
+func validateIpv6AddressMatcher(v interface{}, k string) ([]string, []error) {
+	value, ok := v.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+
+	matcher := strings.TrimPrefix(value, "!")
+	valid := isIpv6AddressOrPrefix(matcher)
+	if bounds := strings.Split(matcher, "-"); len(bounds) == 2 {
+		valid = isIpv6Address(bounds[0]) && isIpv6Address(bounds[1])
+	}
+
+	if !valid {
+		return nil, []error{fmt.Errorf("expected %s to be an IPv6 address, prefix or range, got %q", k, value)}
+	}
+	return nil, nil
+}
+
+func isIpv6Address(s string) bool {
+	ip := net.ParseIP(s)
+	return ip != nil && ip.To4() == nil
+}
+
+func isIpv6AddressOrPrefix(s string) bool {
+	if ip, _, err := net.ParseCIDR(s); err == nil {
+		return ip.To4() == nil
+	}
+	return isIpv6Address(s)
+}
+
+// validateVlanIds accepts a VLAN id, such as 10, or a range of ids, such as
+// 100-200, from 1 to 4094.
+func validateVlanIds(v interface{}, k string) ([]string, []error) {
+	value, ok := v.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+
+	bounds := strings.Split(value, "-")
+	valid := len(bounds) <= 2
+	previous := 0
+	for _, bound := range bounds {
+		id, err := strconv.Atoi(bound)
+		if err != nil || id < 1 || id > 4094 || id < previous {
+			valid = false
+		}
+		previous = id
+	}
+
+	if !valid {
+		return nil, []error{fmt.Errorf("expected %s to be a VLAN id or range of ids from 1 to 4094, got %q", k, value)}
+	}
+	return nil, nil
+}
@@ -0,0 +1,111 @@
+package mikrotik
+
+import (
+	"testing"
+)
+
+func TestMikrotikProvider_ValidateIpv6(t *testing.T) {
+	tests := []struct {
+		value   string
+		prefix  bool
+		matcher bool
+	}{
+		{"2001:db8::1", true, true},
+		{"2001:db8::/32", true, true},
+		{"::/0", true, true},
+		{"!2001:db8::/32", false, true},
+		{"2001:db8::1-2001:db8::ff", false, true},
+		{"!2001:db8::1-2001:db8::ff", false, true},
+		{"192.168.88.1", false, false},
+		{"192.168.88.0/24", false, false},
+		{"2001:db8::/129", false, false},
+		{"2001:db8::1-192.168.88.1", false, false},
+		{"2001:db8::1-", false, false},
+		{"", false, false},
+		{"host.example.com", false, false},
+	}
+
+	for _, test := range tests {
+		if _, errs := validateIpv6AddressOrPrefix(test.value, "address"); (len(errs) == 0) != test.prefix {
+			t.Errorf("validateIpv6AddressOrPrefix(%q) returned %v, expected valid=%v", test.value, errs, test.prefix)
+		}
+		if _, errs := validateIpv6AddressMatcher(test.value, "src_address"); (len(errs) == 0) != test.matcher {
+			t.Errorf("validateIpv6AddressMatcher(%q) returned %v, expected valid=%v", test.value, errs, test.matcher)
+		}
+	}
+
+	if _, errs := validateIpv6AddressOrPrefix(1, "address"); len(errs) == 0 {
+		t.Error("validateIpv6AddressOrPrefix should reject a value which is not a string")
+	}
+}
+
+func TestMikrotikProvider_ValidateIpPrefix(t *testing.T) {
+	tests := []struct {
+		value  string
+		ipv4   bool
+		prefix bool
+	}{
+		{"192.168.88.1/24", true, true},
+		{"192.168.88.0/24", true, true},
+		{"10.0.0.1/32", true, true},
+		{"2001:db8::1/64", false, true},
+		{"192.168.88.1", false, false},
+		{"192.168.88.1/33", false, false},
+		{"192.168.88.256/24", false, false},
+		{"2001:db8::1", false, false},
+		{"", false, false},
+	}
+
+	for _, test := range tests {
+		if _, errs := validateIpv4AddressPrefix(test.value, "address"); (len(errs) == 0) != test.ipv4 {
+			t.Errorf("validateIpv4AddressPrefix(%q) returned %v, expected valid=%v", test.value, errs, test.ipv4)
+		}
+		if _, errs := validateIpPrefix(test.value, "allowed_address"); (len(errs) == 0) != test.prefix {
+			t.Errorf("validateIpPrefix(%q) returned %v, expected valid=%v", test.value, errs, test.prefix)
+		}
+	}
+}
+
+func TestMikrotikProvider_ValidateVlanIds(t *testing.T) {
+	tests := []struct {
+		value string
+		valid bool
+	}{
+		{"1", true},
+		{"4094", true},
+		{"100-200", true},
+		{"100-100", true},
+		{"0", false},
+		{"4095", false},
+		{"200-100", false},
+		{"1-2-3", false},
+		{"10,20", false},
+		{"", false},
+	}
+
+	for _, test := range tests {
+		if _, errs := validateVlanIds(test.value, "vlan_ids"); (len(errs) == 0) != test.valid {
+			t.Errorf("validateVlanIds(%q) returned %v, expected valid=%v", test.value, errs, test.valid)
+		}
+	}
+}
+
+func TestMikrotikProvider_ValidateDuration(t *testing.T) {
+	tests := []struct {
+		value string
+		valid bool
+	}{
+		{"1d", true},
+		{"1w2d3h4m5s", true},
+		{"00:05:00", true},
+		{"300", true},
+		{"1y", false},
+		{"one day", false},
+	}
+
+	for _, test := range tests {
+		if _, errs := validateDuration(test.value, "timeout"); (len(errs) == 0) != test.valid {
+			t.Errorf("validateDuration(%q) returned %v, expected valid=%v", test.value, errs, test.valid)
+		}
+	}
+}
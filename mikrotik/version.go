@@ -0,0 +1,59 @@
+package mikrotik
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// routerVersion caches the major RouterOS version of the router, shared by
+// every copy of a mikrotikConfig.
+type routerVersion struct {
+	mu    sync.Mutex
+	major int
+}
+
+// majorVersion returns the major RouterOS version of the router, such as 7.
+// It is printed by `/system/resource` the first time it is needed; failures
+// are not cached so a transient error does not stick for the whole run.
+func (client mikrotikConfig) majorVersion() (int, error) {
+	client.version.mu.Lock()
+	defer client.version.mu.Unlock()
+
+	if client.version.major > 0 {
+		return client.version.major, nil
+	}
+
+	r, err := client.Run([]string{"/system/resource/print"})
+	if err != nil {
+		return 0, err
+	}
+
+	resource := &struct {
+		Version string `mikrotik:"version"`
+	}{}
+	if err := Unmarshal(*r, resource); err != nil {
+		return 0, err
+	}
+
+	major, err := parseMajorVersion(resource.Version)
+	if err != nil {
+		return 0, err
+	}
+
+	log.Printf("[DEBUG] Detected RouterOS version %s", resource.Version)
+	client.version.major = major
+	return major, nil
+}
+
+// parseMajorVersion returns the major version of a RouterOS version such as
+// `7.12.1 (stable)`.
+func parseMajorVersion(version string) (int, error) {
+	major, err := strconv.Atoi(strings.SplitN(version, ".", 2)[0])
+	if err != nil || major <= 0 {
+		return 0, fmt.Errorf("unable to parse the RouterOS version `%s`", version)
+	}
+	return major, nil
+}
@@ -0,0 +1,28 @@
+package mikrotik
+
+import (
+	"testing"
+)
+
+func TestMikrotikProvider_ParseMajorVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		major   int
+		err     bool
+	}{
+		{"7.12.1 (stable)", 7, false},
+		{"6.49.10 (long-term)", 6, false},
+		{"7.15rc2 (testing)", 7, false},
+		{"7.15 (stable)", 7, false},
+		{"", 0, true},
+		{"stable", 0, true},
+		{"0.1", 0, true},
+	}
+
+	for _, test := range tests {
+		major, err := parseMajorVersion(test.version)
+		if (err != nil) != test.err || major != test.major {
+			t.Errorf("parseMajorVersion(%q) returned %d, %v, expected %d and error=%v", test.version, major, err, test.major, test.err)
+		}
+	}
+}